@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"io"
 	"os"
 	"os/user"
@@ -9,7 +10,7 @@ import (
 
 	"github.com/luisnquin/nao/v3/internal"
 	"github.com/luisnquin/nao/v3/internal/cmd"
-	"github.com/luisnquin/nao/v3/internal/config"
+	configpkg "github.com/luisnquin/nao/v3/internal/config"
 	"github.com/luisnquin/nao/v3/internal/data"
 	"github.com/luisnquin/nao/v3/internal/ui"
 	"github.com/rs/zerolog"
@@ -38,11 +39,12 @@ func main() {
 		logger = zerolog.New(logFile)
 	}
 
+	// Only the system keyring needs it, and it reports the error by itself
 	caller, err := user.Current()
 	if err != nil {
-		logger.Err(err).Msg("unable to get current user, incoming panic")
+		logger.Err(err).Msg("unable to get current user")
 
-		panic(err) // Otherwise the program cannot be used and nothing will be broken
+		caller = &user.User{}
 	}
 
 	logger.Trace().
@@ -54,12 +56,26 @@ func main() {
 
 	logger.Trace().Msg("loading configuration...")
 
-	config, err := config.New(&logger)
+	config, err := configpkg.New(&logger)
 	if err != nil {
 		logger.Err(err).Msg("an error was encountered while loading configuration...")
 
-		ui.Error(err.Error())
-		os.Exit(1)
+		// The 'config' command is the way to fix an invalid file
+		if i := cmd.Subcommand(os.Args[1:]); !errors.Is(err, configpkg.ErrInvalidFile) || i == -1 || os.Args[i+1] != "config" {
+			ui.Error(err.Error())
+			os.Exit(1)
+		}
+	}
+
+	ctx := context.Background()
+
+	if ok, err := cmd.ExecuteWithoutData(ctx, &logger, config, os.Args[1:]); ok {
+		if err != nil {
+			logger.Err(err).Msg("an error was encountered while executing command...")
+			os.Exit(1)
+		}
+
+		return
 	}
 
 	logger.Trace().Msg("loading data...")
@@ -74,11 +90,15 @@ func main() {
 
 	logger.Trace().Msg("executing command...")
 
-	ctx := context.Background()
-
 	if err := cmd.Execute(ctx, &logger, config, data); err != nil {
 		logger.Err(err).Msg("an error was encountered while executing command...")
 
+		var exitCode cmd.ExitCodeError
+
+		if errors.As(err, &exitCode) {
+			os.Exit(int(exitCode))
+		}
+
 		ui.Error(err.Error())
 		os.Exit(1)
 	}
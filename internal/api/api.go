@@ -0,0 +1,355 @@
+package api
+
+import (
+	"crypto/subtle"
+	_ "embed"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-json"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/models"
+	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/rs/zerolog"
+)
+
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+// Server exposes the notes through a JSON API. Every request goes
+// through the same repository used by the CLI commands.
+type Server struct {
+	log   *zerolog.Logger
+	data  *data.Buffer
+	token string
+
+	// The buffer isn't safe for concurrent use.
+	mu sync.Mutex
+}
+
+type (
+	noteView struct {
+		Key string `json:"key"`
+		models.Note
+	}
+
+	createNoteBody struct {
+		Tag     string `json:"tag"`
+		Content string `json:"content"`
+	}
+
+	updateNoteBody struct {
+		Tag     *string `json:"tag"`
+		Content *string `json:"content"`
+	}
+
+	renameTagBody struct {
+		Old string `json:"old"`
+		New string `json:"new"`
+	}
+)
+
+func New(log *zerolog.Logger, data *data.Buffer, token string) *Server {
+	return &Server{
+		log:   log,
+		data:  data,
+		token: token,
+	}
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/openapi.yaml", s.openAPI)
+	mux.Handle("/notes", s.protected(s.notes))
+	mux.Handle("/notes/", s.protected(s.noteByRef))
+	mux.Handle("/search", s.protected(s.search))
+	mux.Handle("/tags/rename", s.protected(s.renameTag))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, fmt.Errorf("%w: %s", ErrRouteNotFound, r.URL.Path))
+	})
+
+	return mux
+}
+
+// Rejects the requests without a valid bearer token and serializes the
+// access to the data buffer, which is reloaded to see changes made by
+// other nao processes.
+func (s *Server) protected(handler func(w http.ResponseWriter, r *http.Request) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.log.Trace().Str("method", r.Method).Str("path", r.URL.Path).Msg("incoming request")
+
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			s.log.Trace().Msg("request rejected, invalid token")
+
+			writeError(w, ErrUnauthorized)
+
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if err := s.refresh(); err != nil {
+			s.log.Err(err).Msg("unable to reload data")
+
+			writeError(w, err)
+
+			return
+		}
+
+		if err := handler(w, r); err != nil {
+			s.log.Err(err).Str("path", r.URL.Path).Msg("request failed")
+
+			writeError(w, err)
+		}
+	})
+}
+
+func (s *Server) refresh() error {
+	s.data.Notes = nil
+
+	return s.data.Reload()
+}
+
+func (s *Server) openAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, ErrMethodNotAllowed)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(openAPISpec)
+}
+
+func (s *Server) notes(w http.ResponseWriter, r *http.Request) error {
+	repo := note.NewRepository(s.data)
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, toViews(repo.Slice(), revealed(r)))
+
+		return nil
+
+	case http.MethodPost:
+		var body createNoteBody
+
+		if err := decodeBody(r, &body); err != nil {
+			return err
+		}
+
+		if body.Content == "" {
+			return fmt.Errorf("%w: empty content, will not be saved", ErrInvalidBody)
+		}
+
+		var options []note.ModifyOption
+
+		if body.Tag != "" {
+			options = append(options, note.WithTag(body.Tag))
+		}
+
+		key, err := repo.New(body.Content, options...)
+		if err != nil {
+			return err
+		}
+
+		nt, err := repo.Peek(key)
+		if err != nil {
+			return err
+		}
+
+		writeJSON(w, http.StatusCreated, newView(nt, revealed(r)))
+
+		return nil
+	}
+
+	return ErrMethodNotAllowed
+}
+
+func (s *Server) noteByRef(w http.ResponseWriter, r *http.Request) error {
+	ref := strings.TrimPrefix(r.URL.Path, "/notes/")
+	if ref == "" || strings.Contains(ref, "/") {
+		return fmt.Errorf("%w: %s", ErrRouteNotFound, r.URL.Path)
+	}
+
+	repo := note.NewRepository(s.data)
+
+	search := note.SearchByPrefix
+	if r.Method == http.MethodDelete {
+		search = note.Resolve // Destructive, an ambiguous reference is not enough
+	}
+
+	key, err := search(ref, s.data)
+	if err != nil {
+		return err
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		// Reading through the API isn't a pick
+		nt, err := repo.Peek(key)
+		if err != nil {
+			return err
+		}
+
+		writeJSON(w, http.StatusOK, newView(nt, revealed(r)))
+
+		return nil
+
+	case http.MethodPatch:
+		var body updateNoteBody
+
+		if err := decodeBody(r, &body); err != nil {
+			return err
+		}
+
+		var options []note.ModifyOption
+
+		if body.Tag != nil && *body.Tag != s.data.Notes[key].Tag {
+			if err := note.NewTagger(s.data).IsValidAsNew(*body.Tag); err != nil {
+				return err
+			}
+
+			options = append(options, note.WithTag(*body.Tag))
+		}
+
+		if body.Content != nil && *body.Content != s.data.Notes[key].Content {
+			if s.data.Notes[key].Locked {
+				return note.ErrNoteLocked
+			}
+
+			options = append(options, note.WithContent(*body.Content))
+		}
+
+		if err := repo.Update(key, options...); err != nil {
+			return err
+		}
+
+		nt, err := repo.Peek(key)
+		if err != nil {
+			return err
+		}
+
+		writeJSON(w, http.StatusOK, newView(nt, revealed(r)))
+
+		return nil
+
+	case http.MethodDelete:
+		if err := repo.Delete(key); err != nil {
+			return err
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+
+	return ErrMethodNotAllowed
+}
+
+func (s *Server) search(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return ErrMethodNotAllowed
+	}
+
+	query := strings.ToLower(r.URL.Query().Get("q"))
+	notes := note.NewRepository(s.data).Slice()
+
+	results := make([]models.Note, 0, len(notes))
+
+	for _, n := range notes {
+		// The secrets can't be found by guessing them
+		n.Content = n.MaskedContent()
+
+		if strings.HasPrefix(strings.ToLower(n.Tag), query) || strings.HasPrefix(strings.ToLower(n.Key), query) ||
+			strings.Contains(strings.ToLower(n.Content), query) {
+			results = append(results, n)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, toViews(results, false))
+
+	return nil
+}
+
+func (s *Server) renameTag(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return ErrMethodNotAllowed
+	}
+
+	var body renameTagBody
+
+	if err := decodeBody(r, &body); err != nil {
+		return err
+	}
+
+	if err := note.NewTagger(s.data).IsValidAsNew(body.New); err != nil {
+		return fmt.Errorf("tag %s is not valid: %w", body.New, err)
+	}
+
+	key, err := note.Resolve(body.Old, s.data)
+	if err != nil {
+		return err
+	}
+
+	repo := note.NewRepository(s.data)
+
+	if err := repo.Update(key, note.WithTag(body.New)); err != nil {
+		return err
+	}
+
+	nt, err := repo.Peek(key)
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, newView(nt, revealed(r)))
+
+	return nil
+}
+
+func decodeBody(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidBody, err.Error())
+	}
+
+	return nil
+}
+
+// Sorted by last update, the same order used by the 'ls' command.
+func toViews(notes []models.Note, reveal bool) []noteView {
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].LastUpdate.After(notes[j].LastUpdate)
+	})
+
+	views := make([]noteView, len(notes))
+
+	for i, n := range notes {
+		views[i] = newView(n, reveal)
+	}
+
+	return views
+}
+
+// Returns the view of the note, with the secrets masked unless they're revealed.
+func newView(n models.Note, reveal bool) noteView {
+	if !reveal {
+		n.Content = n.MaskedContent()
+	}
+
+	return noteView{Key: n.Key, Note: n}
+}
+
+// Reports whether the secrets are requested, like 'nao cat --reveal'.
+func revealed(r *http.Request) bool {
+	reveal, _ := strconv.ParseBool(r.URL.Query().Get("reveal"))
+
+	return reveal
+}
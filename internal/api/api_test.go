@@ -0,0 +1,112 @@
+package api_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/luisnquin/nao/v3/internal/api"
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/secrets"
+	"github.com/rs/zerolog"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	dir := t.TempDir()
+	logger := zerolog.Nop()
+
+	conf := &config.Core{
+		FS: config.FSConfig{
+			DataNormalFile: path.Join(dir, "data.json"),
+			CacheDir:       path.Join(dir, "cache"),
+			DataDir:        dir,
+		},
+	}
+
+	buffer, err := data.NewBuffer(&logger, conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(api.New(&logger, buffer, "secret").Handler())
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func doRequest(t *testing.T, method, url, token, body string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer res.Body.Close()
+
+	content, _ := io.ReadAll(res.Body)
+
+	return res.StatusCode, string(content)
+}
+
+func TestServer(t *testing.T) {
+	server := newTestServer(t)
+
+	checks := []struct {
+		method, path, token, body string
+		status                    int
+		contains                  string
+	}{
+		{http.MethodGet, "/notes", "", "", http.StatusUnauthorized, "unauthorized"},
+		{http.MethodGet, "/notes", "wrong", "", http.StatusUnauthorized, "unauthorized"},
+		{http.MethodGet, "/openapi.yaml", "", "", http.StatusOK, "openapi"},
+		{http.MethodPost, "/notes", "secret", `{"tag":"groceries","content":"milk"}`, http.StatusCreated, `"tag":"groceries"`},
+		{http.MethodPost, "/notes", "secret", `{"tag":"groceries","content":"eggs"}`, http.StatusConflict, "tag_already_exists"},
+		{http.MethodPost, "/notes", "secret", `{"tag":"not valid","content":"eggs"}`, http.StatusUnprocessableEntity, "tag_invalid"},
+		{http.MethodPost, "/notes", "secret", `{"tag":"empty"}`, http.StatusBadRequest, "invalid_body"},
+		{http.MethodGet, "/notes/groc", "secret", "", http.StatusOK, `"content":"milk"`},
+		{http.MethodGet, "/notes/groc", "secret", "", http.StatusOK, `"picks":0`},
+		{http.MethodPost, "/notes", "secret", `{"tag":"wifi","content":"pass: [[secret:hunter2]]"}`, http.StatusCreated, `"tag":"wifi"`},
+		{http.MethodGet, "/notes/wifi", "secret", "", http.StatusOK, `"content":"pass: ` + secrets.Mask + `"`},
+		{http.MethodGet, "/notes/wifi?reveal=true", "secret", "", http.StatusOK, "hunter2"},
+		{http.MethodGet, "/notes", "secret", "", http.StatusOK, `"content":"pass: ` + secrets.Mask + `"`},
+		{http.MethodDelete, "/notes/wifi", "secret", "", http.StatusNoContent, ""},
+		{http.MethodPatch, "/notes/groceries", "secret", `{"content":"bread"}`, http.StatusOK, `"version":2`},
+		{http.MethodGet, "/search?q=bre", "secret", "", http.StatusOK, "groceries"},
+		{http.MethodPost, "/notes", "secret", `{"tag":"Pantry","content":"rice"}`, http.StatusCreated, `"tag":"Pantry"`},
+		{http.MethodGet, "/search?q=pan", "secret", "", http.StatusOK, "Pantry"},
+		{http.MethodDelete, "/notes/Pantry", "secret", "", http.StatusNoContent, ""},
+		{http.MethodPost, "/tags/rename", "secret", `{"old":"groceries","new":"shopping"}`, http.StatusOK, `"tag":"shopping"`},
+		{http.MethodGet, "/notes/groceries", "secret", "", http.StatusNotFound, "note_not_found"},
+		{http.MethodDelete, "/notes/shopping", "secret", "", http.StatusNoContent, ""},
+		{http.MethodGet, "/notes", "secret", "", http.StatusOK, "[]"},
+		{http.MethodPut, "/notes", "secret", "", http.StatusMethodNotAllowed, "method_not_allowed"},
+	}
+
+	for _, check := range checks {
+		status, body := doRequest(t, check.method, server.URL+check.path, check.token, check.body)
+
+		if status != check.status {
+			t.Errorf("%s %s: expected status %d, but got %d: %s", check.method, check.path, check.status, status, body)
+		}
+
+		if !strings.Contains(body, check.contains) {
+			t.Errorf("%s %s: expected '%s' in body, but got '%s'", check.method, check.path, check.contains, body)
+		}
+	}
+}
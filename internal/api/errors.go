@@ -0,0 +1,69 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/goccy/go-json"
+	"github.com/luisnquin/nao/v3/internal/note"
+)
+
+var (
+	ErrUnauthorized     = errors.New("missing or invalid token")
+	ErrMethodNotAllowed = errors.New("method not allowed")
+	ErrRouteNotFound    = errors.New("route not found")
+	ErrInvalidBody      = errors.New("invalid request body")
+)
+
+type errorBody struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Maps the sentinel errors of the program to an HTTP status code and
+// a stable machine-readable code, anything else is an internal error.
+func statusFromError(err error) (int, string) {
+	switch {
+	case errors.Is(err, note.ErrNoteNotFound):
+		return http.StatusNotFound, "note_not_found"
+	case errors.Is(err, ErrRouteNotFound):
+		return http.StatusNotFound, "route_not_found"
+	case errors.Is(err, note.ErrAmbiguousPrefix):
+		return http.StatusConflict, "ambiguous_prefix"
+	case errors.Is(err, note.ErrTagAlreadyExists):
+		return http.StatusConflict, "tag_already_exists"
+	case errors.Is(err, note.ErrNoteLocked):
+		return http.StatusConflict, "note_locked"
+	case errors.Is(err, note.ErrTagInvalid):
+		return http.StatusUnprocessableEntity, "tag_invalid"
+	case errors.Is(err, note.ErrTagNotProvided):
+		return http.StatusUnprocessableEntity, "tag_not_provided"
+	case errors.Is(err, ErrInvalidBody):
+		return http.StatusBadRequest, "invalid_body"
+	case errors.Is(err, ErrUnauthorized):
+		return http.StatusUnauthorized, "unauthorized"
+	case errors.Is(err, ErrMethodNotAllowed):
+		return http.StatusMethodNotAllowed, "method_not_allowed"
+	}
+
+	return http.StatusInternalServerError, "internal"
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status, code := statusFromError(err)
+
+	writeJSON(w, status, errorBody{
+		Error: errorDetail{Code: code, Message: err.Error()},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
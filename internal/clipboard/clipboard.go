@@ -0,0 +1,169 @@
+// Package clipboard copies to and pastes from the system clipboard with
+// the available tools, or with the OSC 52 escape sequence otherwise.
+package clipboard
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// The name of the detected backend.
+const Auto = "auto"
+
+var (
+	ErrUnknownBackend   = errors.New("unknown clipboard backend")
+	ErrPasteUnsupported = errors.New("the terminal clipboard(OSC 52) can't be read, install wl-clipboard, xclip or xsel")
+)
+
+type Backend struct {
+	Name string
+	// Environment variable required by the tool, like DISPLAY.
+	env   string
+	copy  []string
+	paste []string
+	clear []string
+}
+
+// The OSC 52 backend, it asks the terminal emulator to store the
+// content, which also works through SSH.
+var OSC52 = Backend{Name: "osc52"}
+
+// Ordered by preference.
+var backends = []Backend{
+	{
+		Name: "wl-clipboard", env: "WAYLAND_DISPLAY",
+		copy: []string{"wl-copy"}, paste: []string{"wl-paste", "--no-newline"}, clear: []string{"wl-copy", "--clear"},
+	},
+	{
+		Name: "xclip", env: "DISPLAY",
+		copy: []string{"xclip", "-selection", "clipboard", "-in"}, paste: []string{"xclip", "-selection", "clipboard", "-out"},
+	},
+	{
+		Name: "xsel", env: "DISPLAY",
+		copy: []string{"xsel", "--clipboard", "--input"}, paste: []string{"xsel", "--clipboard", "--output"},
+	},
+	{
+		Name: "pbcopy",
+		copy: []string{"pbcopy"}, paste: []string{"pbpaste"},
+	},
+}
+
+// Returns the names of the supported backends.
+func Backends() []string {
+	names := []string{Auto}
+
+	for _, b := range backends {
+		names = append(names, b.Name)
+	}
+
+	return append(names, OSC52.Name)
+}
+
+// Returns the backend with the name or the first available one if the name is
+// empty or 'auto'. The first available is the first tool installed, with its
+// display in the environment, or OSC 52 if there's none.
+func Detect(name string) (Backend, error) {
+	if name != "" && name != Auto {
+		if name == OSC52.Name {
+			return OSC52, nil
+		}
+
+		for _, b := range backends {
+			if b.Name == name {
+				return b, nil
+			}
+		}
+
+		return Backend{}, fmt.Errorf("%w '%s', expected one of: %s", ErrUnknownBackend, name, strings.Join(Backends(), ", "))
+	}
+
+	for _, b := range backends {
+		if b.env != "" && os.Getenv(b.env) == "" {
+			continue
+		}
+
+		if b.Name == "pbcopy" && runtime.GOOS != "darwin" {
+			continue
+		}
+
+		if _, err := exec.LookPath(b.copy[0]); err == nil {
+			return b, nil
+		}
+	}
+
+	return OSC52, nil
+}
+
+func (b Backend) Copy(content string) error {
+	if b.Name == OSC52.Name {
+		return writeOSC52(base64.StdEncoding.EncodeToString([]byte(content)))
+	}
+
+	cmd := exec.Command(b.copy[0], b.copy[1:]...)
+	cmd.Stdin = strings.NewReader(content)
+
+	return run(cmd)
+}
+
+func (b Backend) Paste() (string, error) {
+	if b.Name == OSC52.Name {
+		return "", ErrPasteUnsupported
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command(b.paste[0], b.paste[1:]...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %w: %s", cmd.Args[0], err, msg)
+		}
+
+		return "", fmt.Errorf("%s: %w", cmd.Args[0], err)
+	}
+
+	return stdout.String(), nil
+}
+
+func (b Backend) Clear() error {
+	switch {
+	case b.Name == OSC52.Name:
+		return writeOSC52("")
+	case len(b.clear) > 0:
+		return run(exec.Command(b.clear[0], b.clear[1:]...))
+	}
+
+	return b.Copy("")
+}
+
+// Writes the sequence to the terminal, even if the output is redirected.
+func writeOSC52(encoded string) error {
+	out := os.Stdout
+
+	if tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0); err == nil {
+		defer tty.Close()
+
+		out = tty
+	}
+
+	_, err := fmt.Fprintf(out, "\x1b]52;c;%s\a", encoded)
+
+	return err
+}
+
+// The output of the tools isn't captured for copying because xclip
+// and wl-copy keep running in the background to own the selection.
+func run(cmd *exec.Cmd) error {
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %w", cmd.Args[0], err)
+	}
+
+	return nil
+}
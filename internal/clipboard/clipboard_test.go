@@ -0,0 +1,69 @@
+package clipboard_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/luisnquin/nao/v3/internal/clipboard"
+)
+
+// A fake xclip that keeps the clipboard in a file next to it.
+const fakeXclip = `#!/bin/sh
+case "$3" in
+-in) /bin/cat > "${0%/*}/clipboard" ;;
+-out) /bin/cat "${0%/*}/clipboard" ;;
+esac
+`
+
+func TestDetect(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Setenv("PATH", dir)
+	t.Setenv("WAYLAND_DISPLAY", "")
+	t.Setenv("DISPLAY", ":0")
+
+	if b, err := clipboard.Detect(""); err != nil || b.Name != clipboard.OSC52.Name {
+		t.Errorf("expected the OSC 52 backend without tools, got %q and %v", b.Name, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "xclip"), []byte(fakeXclip), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := clipboard.Detect(clipboard.Auto)
+	if err != nil || b.Name != "xclip" {
+		t.Fatalf("expected xclip, got %q and %v", b.Name, err)
+	}
+
+	if err := b.Copy("hello\nworld"); err != nil {
+		t.Fatal(err)
+	}
+
+	if content, err := b.Paste(); err != nil || content != "hello\nworld" {
+		t.Errorf("expected the copied content, got %q and %v", content, err)
+	}
+
+	if err := b.Clear(); err != nil {
+		t.Fatal(err)
+	}
+
+	if content, err := b.Paste(); err != nil || content != "" {
+		t.Errorf("expected an empty clipboard, got %q and %v", content, err)
+	}
+
+	t.Setenv("DISPLAY", "")
+
+	if b, _ := clipboard.Detect(""); b.Name != clipboard.OSC52.Name {
+		t.Errorf("expected the OSC 52 backend without display, got %q", b.Name)
+	}
+
+	if _, err := clipboard.Detect("clippy"); !errors.Is(err, clipboard.ErrUnknownBackend) {
+		t.Errorf("expected an unknown backend error, got %v", err)
+	}
+
+	if _, err := clipboard.OSC52.Paste(); !errors.Is(err, clipboard.ErrPasteUnsupported) {
+		t.Errorf("expected an unsupported paste error, got %v", err)
+	}
+}
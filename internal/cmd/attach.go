@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/models"
+	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/luisnquin/nao/v3/internal/utils"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+type AttachCmd struct {
+	*cobra.Command
+
+	log    *zerolog.Logger
+	config *config.Core
+	data   *data.Buffer
+	name   string
+}
+
+func BuildAttach(log *zerolog.Logger, config *config.Core, data *data.Buffer) AttachCmd {
+	c := AttachCmd{
+		Command: &cobra.Command{
+			Use:   "attach <id> | <tag> <file>...",
+			Short: "Store files alongside a note, '-' reads the standard input",
+			Example: `  nao attach trip tickets.pdf map.png
+  curl -s https://example.com/logo.png | nao attach brand - --name logo.png`,
+			Args:          cobra.MinimumNArgs(2),
+			SilenceUsage:  true,
+			SilenceErrors: true,
+			ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+				if len(args) != 0 {
+					return nil, cobra.ShellCompDirectiveDefault
+				}
+
+				return KeyTagCompletions(data)(cmd, args, toComplete)
+			},
+		},
+		config: config,
+		data:   data,
+		log:    log,
+	}
+
+	c.RunE = c.Main()
+
+	log.Trace().Msg("the 'attach' command has been created")
+
+	c.Flags().StringVar(&c.name, "name", "", "the name of the attachment, by default the name of the file")
+
+	return c
+}
+
+func (c *AttachCmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		files := args[1:]
+
+		if c.name != "" && len(files) > 1 {
+			return fmt.Errorf("--name can only be used with a single file")
+		}
+
+		key, err := SearchOrPick(c.config, c.data, args[0], true)
+		if err != nil {
+			return err
+		}
+
+		nt, err := note.NewRepository(c.data).Peek(key)
+		if err != nil {
+			return err
+		}
+
+		// The attachments are as private as the content of the note
+		if _, _, err := unlockNote(nt); err != nil {
+			return err
+		}
+
+		blobs := c.data.Blobs()
+		modifiers := make([]note.ModifyOption, 0, len(files))
+
+		for _, file := range files {
+			name := c.name
+			if name == "" {
+				name = filepath.Base(file)
+			}
+
+			if name == "-" {
+				return fmt.Errorf("the standard input needs a name, use --name")
+			}
+
+			content, err := readAttachment(file)
+			if err != nil {
+				return err
+			}
+
+			hash, err := blobs.Put(content)
+			if err != nil {
+				return err
+			}
+
+			c.log.Trace().Str("name", name).Str("hash", hash).Int("size", len(content)).Msg("attachment stored")
+
+			modifiers = append(modifiers, note.WithAttachment(models.Attachment{
+				Name:    name,
+				Hash:    hash,
+				Size:    int64(len(content)),
+				AddedAt: time.Now(),
+			}))
+		}
+
+		notesRepo := note.NewRepository(c.data)
+
+		if err := notesRepo.Update(key, modifiers...); err != nil {
+			return err
+		}
+
+		// The replaced attachments could be unused now
+		return blobs.Prune(c.data.Notes)
+	}
+}
+
+func readAttachment(file string) ([]byte, error) {
+	if file == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+
+	if !utils.FileExists(file) {
+		return nil, fmt.Errorf("file '%s' not found", file)
+	}
+
+	return os.ReadFile(file)
+}
+
+type DetachCmd struct {
+	*cobra.Command
+
+	log    *zerolog.Logger
+	config *config.Core
+	data   *data.Buffer
+}
+
+func BuildDetach(log *zerolog.Logger, config *config.Core, data *data.Buffer) DetachCmd {
+	c := DetachCmd{
+		Command: &cobra.Command{
+			Use:               "detach <id> | <tag> <name>...",
+			Short:             "Remove attachments from a note",
+			Args:              cobra.MinimumNArgs(2),
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: attachmentCompletions(data),
+		},
+		config: config,
+		data:   data,
+		log:    log,
+	}
+
+	c.RunE = c.Main()
+
+	log.Trace().Msg("the 'detach' command has been created")
+
+	return c
+}
+
+func (c *DetachCmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		key, err := SearchOrPick(c.config, c.data, args[0], true)
+		if err != nil {
+			return err
+		}
+
+		notesRepo := note.NewRepository(c.data)
+
+		nt, err := notesRepo.Peek(key)
+		if err != nil {
+			return err
+		}
+
+		// The attachments are as private as the content of the note
+		if _, _, err := unlockNote(nt); err != nil {
+			return err
+		}
+
+		modifiers := make([]note.ModifyOption, 0, len(args)-1)
+
+		for _, name := range args[1:] {
+			if _, ok := nt.Attachment(name); !ok {
+				return fmt.Errorf("%w: %s", note.ErrAttachmentNotFound, name)
+			}
+
+			modifiers = append(modifiers, note.WithoutAttachment(name))
+		}
+
+		c.log.Trace().Str("key", key).Strs("names", args[1:]).Msg("removing attachments...")
+
+		if err := notesRepo.Update(key, modifiers...); err != nil {
+			return err
+		}
+
+		return c.data.Blobs().Prune(c.data.Notes)
+	}
+}
+
+// Completes the note and then the names of its attachments.
+func attachmentCompletions(data *data.Buffer) func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return KeyTagCompletions(data)(cmd, args, toComplete)
+		}
+
+		key, err := note.Resolve(args[0], data)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		nt, err := note.NewRepository(data).Peek(key)
+		if err != nil || nt.Locked {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		names := make([]string, 0, len(nt.Attachments))
+
+		for _, a := range nt.Attachments {
+			if !utils.Contains(args[1:], a.Name) {
+				names = append(names, a.Name)
+			}
+		}
+
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
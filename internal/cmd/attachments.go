@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/goccy/go-json"
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/models"
+	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/luisnquin/nao/v3/internal/ui"
+	"github.com/luisnquin/nao/v3/internal/utils"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/xeonx/timeago"
+)
+
+type AttachmentsCmd struct {
+	*cobra.Command
+
+	log    *zerolog.Logger
+	config *config.Core
+	data   *data.Buffer
+	quiet  bool
+	json   bool
+}
+
+func BuildAttachments(log *zerolog.Logger, config *config.Core, data *data.Buffer) AttachmentsCmd {
+	c := AttachmentsCmd{
+		Command: &cobra.Command{
+			Use:               "attachments [<id> | <tag>]",
+			Short:             "See the files attached to a note",
+			Args:              cobra.MaximumNArgs(1),
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: KeyTagCompletions(data),
+		},
+		config: config,
+		data:   data,
+		log:    log,
+	}
+
+	c.RunE = c.Main()
+
+	log.Trace().Msg("the 'attachments' command has been created")
+
+	flags := c.Flags()
+	flags.BoolVarP(&c.quiet, "quiet", "q", false, "only display the names")
+	flags.BoolVar(&c.json, "json", false, "the displayed output will be in JSON format, sizes in bytes")
+
+	return c
+}
+
+func (c *AttachmentsCmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		var arg string
+		if len(args) == 1 {
+			arg = args[0]
+		}
+
+		key, err := SearchOrPick(c.config, c.data, arg, false)
+		if err != nil {
+			return err
+		}
+
+		nt, err := note.NewRepository(c.data).Peek(key)
+		if err != nil {
+			return err
+		}
+
+		// The attachments are as private as the content of the note
+		if _, _, err := unlockNote(nt); err != nil {
+			return err
+		}
+
+		switch {
+		case c.json:
+			attachments := nt.Attachments
+			if attachments == nil {
+				attachments = []models.Attachment{}
+			}
+
+			return json.NewEncoder(os.Stdout).Encode(attachments)
+		case c.quiet:
+			for _, a := range nt.Attachments {
+				fmt.Fprintln(os.Stdout, a.Name)
+			}
+
+			return nil
+		}
+
+		name := colorOrNop(c.config.Element("attachments", "name", ui.RoleTag).Color, false)
+		size := colorOrNop(c.config.Element("attachments", "size", ui.RoleSize).Color, false)
+		date := colorOrNop(c.config.Element("attachments", "date", ui.RoleDate).Color, false)
+		hash := colorOrNop(c.config.Element("attachments", "hash", ui.RoleID).Color, false)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+		for _, a := range nt.Attachments {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", name.Sprint(a.Name), size.Sprint(utils.SizeToStorageUnits(a.Size)),
+				date.Sprint(timeago.English.Format(a.AddedAt)), hash.Sprint(a.Hash[:12]))
+		}
+
+		return w.Flush()
+	}
+}
@@ -3,63 +3,224 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"os/exec"
+	"strings"
 
+	"github.com/gookit/color"
+	"github.com/luisnquin/nao/v3/internal"
+	"github.com/luisnquin/nao/v3/internal/config"
 	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/format"
+	"github.com/luisnquin/nao/v3/internal/markdown"
+	"github.com/luisnquin/nao/v3/internal/models"
 	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/luisnquin/nao/v3/internal/secrets"
+	"github.com/luisnquin/nao/v3/internal/tui"
+	"github.com/luisnquin/nao/v3/internal/ui"
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 type CatCmd struct {
 	*cobra.Command
 
-	log  *zerolog.Logger
-	data *data.Buffer
+	log     *zerolog.Logger
+	config  *config.Core
+	data    *data.Buffer
+	render  bool
+	noPager bool
+	format  string
+	reveal  bool
 }
 
-func BuildCat(log *zerolog.Logger, data *data.Buffer) CatCmd {
+func BuildCat(log *zerolog.Logger, config *config.Core, data *data.Buffer) CatCmd {
 	c := CatCmd{
 		Command: &cobra.Command{
-			Use:               "cat",
+			Use:               "cat [<id> | <tag>]...",
 			Short:             "Displays the note in the standard output",
-			Args:              cobra.MinimumNArgs(1),
+			Args:              cobra.ArbitraryArgs,
 			SilenceErrors:     true,
 			SilenceUsage:      true,
 			ValidArgsFunction: KeyTagCompletions(data),
 		},
-		data: data,
-		log:  log,
+		config: config,
+		data:   data,
+		log:    log,
 	}
 
 	c.RunE = c.Main()
 
 	log.Trace().Msg("the 'cat' command has been created")
 
+	flags := c.Flags()
+	flags.BoolVarP(&c.render, "render", "r", config.Command.Cat.Render, "render the notes as Markdown when the output is a terminal")
+	flags.BoolVar(&c.noPager, "no-pager", config.Command.Cat.NoPager, "do not send long rendered notes to $PAGER")
+	flags.BoolVar(&c.reveal, "reveal", false, "display the secret values and the content of sensitive notes")
+	flags.StringVar(&c.format, "format", "", "raw output in "+strings.Join(format.Kinds(), "|")+" or a Go template like '{{.Tag}}: {{.Content}}'")
+
+	c.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return format.Kinds(), cobra.ShellCompDirectiveNoFileComp
+	})
+
 	return c
 }
 
-func (c CatCmd) Main() cobra.PositionalArgs {
+func (c *CatCmd) Main() cobra.PositionalArgs {
 	return func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			if !tui.IsInputInteractive() {
+				return cmd.Usage()
+			}
+
+			args = []string{""}
+		}
+
+		// Escape sequences are useless if nobody is going to see them
+		render := c.format == "" && c.render && !internal.NoColor && term.IsTerminal(int(os.Stdout.Fd()))
+
+		c.log.Trace().Bool("render", render).Send()
+
 		nbOfArgs := len(args)
 
+		notesRepo := note.NewRepository(c.data)
+
+		var (
+			output strings.Builder
+			notes  []models.Note
+		)
+
 		for i, arg := range args {
 			c.log.Trace().Msgf("searching key or tag '%s', %d/%d", arg, i+1, nbOfArgs)
 
-			key, err := note.SearchByPrefix(arg, c.data)
+			key, err := SearchOrPick(c.config, c.data, arg, false)
 			if err != nil {
 				c.log.Err(err).Msgf("an error occurred while searching key/tag '%s", arg)
 
 				return err
 			}
 
-			note := c.data.Notes[key]
+			nt, err := notesRepo.Get(key)
+			if err != nil {
+				return err
+			}
+
+			if nt.Content, _, err = unlockNote(nt); err != nil {
+				return err
+			}
+
+			nt.Locked = false
+
+			c.log.Trace().Str("key", key).Str("tag", nt.Tag).Bool("reveal", c.reveal).Send()
+
+			if c.format != "" {
+				// Otherwise the secrets are redacted
+				if c.reveal {
+					nt.Content = secrets.Reveal(nt.Content)
+					nt.Sensitive = false
+				}
+
+				notes = append(notes, nt)
+
+				continue
+			}
+
+			content := nt.MaskedContent()
+			if c.reveal {
+				content = secrets.Reveal(nt.Content)
+			}
+
+			if !render {
+				c.log.Trace().Msg("sending note content to stdout...")
+
+				fmt.Fprintln(os.Stdout, content)
+
+				continue
+			}
+
+			c.log.Trace().Msg("rendering note content...")
 
-			c.log.Trace().Str("key", key).Str("tag", note.Tag).Send()
-			c.log.Trace().Msg("sending note content to stdout...")
+			output.WriteString(markdown.Render(content, c.markdownStyle(), c.terminalWidth()))
+		}
+
+		if c.format != "" {
+			c.log.Trace().Str("format", c.format).Msg("writing notes with custom format...")
+
+			return format.Write(os.Stdout, c.format, notes, append(append([]string{}, format.DefaultFields...), format.FieldContent))
+		}
 
-			fmt.Fprintln(os.Stdout, note.Content)
+		if !render {
+			return nil
 		}
 
-		return nil
+		return c.page(output.String())
 	}
 }
+
+func (c *CatCmd) markdownStyle() markdown.Style {
+	return markdown.Style{
+		Heading:    c.color("heading", ui.RoleAccent),
+		Subheading: c.color("subheading", ui.RoleHeader),
+		Code:       c.color("code", ui.RoleCode),
+		Keyword:    c.color("keyword", ui.RoleKeyword),
+		String:     c.color("string", ui.RoleString),
+		Number:     c.color("number", ui.RoleNumber),
+		Comment:    c.color("comment", ui.RoleComment),
+		Link:       c.color("link", ui.RoleHeader),
+		Quote:      c.color("quote", ui.RoleComment),
+		Bullet:     c.color("bullet", ui.RoleAccent),
+		Rule:       c.color("rule", ui.RoleComment),
+	}
+}
+
+func (c *CatCmd) color(element, role string) color.PrinterFace {
+	return colorOrNop(c.config.Element("cat", element, role).Color, false)
+}
+
+func (c *CatCmd) terminalWidth() int {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 {
+		return 80
+	}
+
+	return width
+}
+
+// Prints the output or sends it to $PAGER if it doesn't fit in
+// the terminal.
+func (c *CatCmd) page(output string) error {
+	_, height, err := term.GetSize(int(os.Stdout.Fd()))
+
+	if c.noPager || err != nil || strings.Count(output, "\n") < height {
+		_, err = fmt.Fprint(os.Stdout, output)
+
+		return err
+	}
+
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if len(pager) == 0 {
+		pager = []string{"less"}
+	}
+
+	if _, err := exec.LookPath(pager[0]); err != nil {
+		c.log.Err(err).Str("pager", pager[0]).Msg("pager not found, writing to stdout")
+
+		_, err = fmt.Fprint(os.Stdout, output)
+
+		return err
+	}
+
+	c.log.Trace().Strs("pager", pager).Msg("sending output to pager...")
+
+	bin := exec.Command(pager[0], pager[1:]...)
+	bin.Stdin = strings.NewReader(output)
+	bin.Stdout = os.Stdout
+	bin.Stderr = os.Stderr
+
+	// Otherwise less would display the escape sequences
+	if os.Getenv("LESS") == "" {
+		bin.Env = append(os.Environ(), "LESS=FRX")
+	}
+
+	return bin.Run()
+}
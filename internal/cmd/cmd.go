@@ -3,14 +3,24 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/luisnquin/nao/v3/internal"
 	"github.com/luisnquin/nao/v3/internal/config"
 	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/tui"
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 )
 
+// Ends the program with the code and without printing anything, like
+// 'nao due --exit-code' when there are overdue notes.
+type ExitCodeError int
+
+func (e ExitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", int(e))
+}
+
 type cobraWriter struct {
 	log *zerolog.Logger
 }
@@ -21,14 +31,55 @@ func (cw cobraWriter) Write(p []byte) (n int, err error) {
 	return
 }
 
+// Returns the position of the subcommand in the arguments of the program,
+// the first one that isn't a flag since the global flags don't take values.
+// It's -1 if there's no subcommand.
+func Subcommand(args []string) int {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+
+		if !strings.HasPrefix(arg, "-") {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Runs the commands that don't use the data, so it's not loaded. It reports
+// false if the arguments are for another command.
+func ExecuteWithoutData(ctx context.Context, log *zerolog.Logger, config *config.Core, args []string) (bool, error) {
+	i := Subcommand(args)
+	if i == -1 || args[i] != clearClipboardCmd {
+		return false, nil
+	}
+
+	// It runs in the background, the secret of the data could be prompted
+	// in the terminal while the user is typing something else
+	c := BuildClearClipboard(log, config)
+	c.SetArgs(args[i+1:])
+
+	return true, c.ExecuteContext(ctx)
+}
+
 func Execute(ctx context.Context, log *zerolog.Logger, config *config.Core, data *data.Buffer) error {
 	log.Trace().Msg("configuring cli...")
 
+	uiCmd := BuildUI(log, config, data)
+
 	root := cobra.Command{
 		Use:   "nao",
 		Short: "nao is a tool to manage your notes",
 		Long:  `Manage your notes or other types of files without worry about the path where it is`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && tui.IsInteractive() {
+				log.Debug().Msg("no command specified in an interactive terminal, opening the ui...")
+
+				return uiCmd.RunE(cmd, args)
+			}
+
 			log.Debug().Strs("args", args).Msg("no command specified, returning usage...")
 
 			return cmd.Usage()
@@ -51,7 +102,7 @@ func Execute(ctx context.Context, log *zerolog.Logger, config *config.Core, data
 	log.Trace().Msg("root command has been created")
 
 	gFlags := root.PersistentFlags()
-	gFlags.BoolVar(&internal.NoColor, "no-color", false, "disable colorized output")
+	gFlags.BoolVar(&internal.NoColor, "no-color", internal.NoColor, "disable colorized output, also with the NO_COLOR environment variable")
 	gFlags.BoolVar(new(bool), "debug", false, "enable debug output, everything is written to stderr")
 	gFlags.MarkHidden("debug")
 
@@ -59,12 +110,41 @@ func Execute(ctx context.Context, log *zerolog.Logger, config *config.Core, data
 	log.Trace().Msg("adding commands to root")
 
 	root.AddCommand(
-		BuildCat(log, data).Command,
+		BuildArchive(log, config, data).Command,
+		BuildAttach(log, config, data).Command,
+		BuildAttachments(log, config, data).Command,
+		BuildCat(log, config, data).Command,
+		BuildConfig(log, config).Command,
+		BuildCp(log, config, data).Command,
+		BuildDetach(log, config, data).Command,
+		BuildDue(log, config, data).Command,
+		BuildExtract(log, config, data).Command,
+		BuildFavorite(log, config, data).Command,
+		BuildJournal(log, config, data).Command,
+		BuildKeys(log, config).Command,
+		BuildLock(log, config, data).Command,
 		BuildLs(log, config, data).Command,
 		BuildMod(log, config, data).Command,
 		BuildNew(log, config, data).Command,
+		BuildPaste(log, config, data).Command,
+		BuildPick(log, config, data).Command,
+		BuildPin(log, config, data).Command,
+		BuildReceive(log, config, data).Command,
+		BuildRecent(log, config, data).Command,
+		BuildRemind(log, config, data).Command,
 		BuildRm(log, config, data).Command,
+		BuildScan(log, config, data).Command,
+		BuildSensitive(log, config, data).Command,
+		BuildServe(log, config, data).Command,
+		BuildShare(log, config, data).Command,
+		BuildStats(log, config, data).Command,
 		BuildTag(log, config, data).Command,
+		BuildTasks(log, config, data).Command,
+		BuildTheme(log, config).Command,
+		BuildToday(log, config, data).Command,
+		BuildUnarchive(log, config, data).Command,
+		BuildUnlock(log, config, data).Command,
+		uiCmd.Command,
 		BuildVersion(log, config).Command,
 	)
 
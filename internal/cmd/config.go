@@ -0,0 +1,356 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/luisnquin/nao/v3/internal"
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/ui"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+type ConfigCmd struct {
+	*cobra.Command
+
+	log    *zerolog.Logger
+	config *config.Core
+	editor string
+	yes    bool
+	origin bool
+}
+
+func BuildConfig(log *zerolog.Logger, config *config.Core) ConfigCmd {
+	c := ConfigCmd{
+		Command: &cobra.Command{
+			Use:               "config",
+			Short:             "Get, set, edit and validate the configuration",
+			Args:              cobra.NoArgs,
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: cobra.NoFileCompletions,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return cmd.Usage()
+			},
+		},
+		config: config,
+		log:    log,
+	}
+
+	get := &cobra.Command{
+		Use:               "get [<key>]",
+		Short:             "Print the effective value of a key or the whole configuration",
+		Args:              cobra.MaximumNArgs(1),
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: configKeyCompletions,
+		RunE:              c.Get(),
+	}
+
+	show := &cobra.Command{
+		Use:               "show",
+		Short:             "Print every effective value of the configuration",
+		Args:              cobra.NoArgs,
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE:              c.Show(),
+	}
+
+	show.Flags().BoolVar(&c.origin, "origin", false, "print the layer(default, system, user, project or env) that sets each value")
+
+	set := &cobra.Command{
+		Use:               "set <key> <value>",
+		Short:             "Set the value of a key in the configuration file",
+		Args:              cobra.ExactArgs(2),
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: configKeyCompletions,
+		RunE:              c.Set(),
+	}
+
+	edit := &cobra.Command{
+		Use:               "edit",
+		Short:             "Open the configuration file in the editor and validate it on save",
+		Args:              cobra.NoArgs,
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE:              c.Edit(),
+	}
+
+	edit.Flags().StringVar(&c.editor, "editor", "", "change the default code editor (ignoring configuration file)")
+
+	path := &cobra.Command{
+		Use:               "path",
+		Short:             "Print the path of the configuration file",
+		Args:              cobra.NoArgs,
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Fprintln(os.Stdout, c.config.FS.ConfigFile)
+
+			return nil
+		},
+	}
+
+	reset := &cobra.Command{
+		Use:               "reset",
+		Short:             "Restore the default configuration file",
+		Args:              cobra.NoArgs,
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE:              c.Reset(),
+	}
+
+	reset.Flags().BoolVarP(&c.yes, "yes", "y", false, "to pretend to be sure")
+
+	c.AddCommand(get, show, set, edit, path, reset)
+
+	log.Trace().Msg("the 'config' command has been created")
+
+	return c
+}
+
+func configKeyCompletions(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return config.Keys(), cobra.ShellCompDirectiveNoFileComp
+}
+
+func (c *ConfigCmd) Get() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		content, err := yaml.Marshal(c.config)
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 0 {
+			fmt.Fprint(os.Stdout, string(content))
+
+			return nil
+		}
+
+		if _, err := config.KeyType(args[0]); err != nil {
+			return err
+		}
+
+		var effective map[string]any
+
+		if err := yaml.Unmarshal(content, &effective); err != nil {
+			return err
+		}
+
+		value, err := NavigateMapAndGet(effective, args[0])
+		if err != nil {
+			// Known key but empty, nothing to print
+			c.log.Trace().Err(err).Str("key", args[0]).Msg("key without value")
+
+			return nil
+		}
+
+		fmt.Fprint(os.Stdout, value)
+
+		return nil
+	}
+}
+
+func (c *ConfigCmd) Show() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		content, err := yaml.Marshal(c.config)
+		if err != nil {
+			return err
+		}
+
+		var effective map[string]any
+
+		if err := yaml.Unmarshal(content, &effective); err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+		for _, key := range config.Keys() {
+			value := configValue(effective, key)
+
+			if !c.origin {
+				fmt.Fprintf(w, "%s\t%s\n", key, value)
+
+				continue
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\n", key, value, c.config.Origin(key))
+		}
+
+		return w.Flush()
+	}
+}
+
+func (c *ConfigCmd) Set() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		key, raw := args[0], args[1]
+
+		value, err := config.ParseValue(key, raw)
+		if err != nil {
+			return err
+		}
+
+		c.log.Trace().Str("key", key).Interface("value", value).Msg("updating configuration file...")
+
+		return updateConfigFile(c.config, key, value)
+	}
+}
+
+// Sets the key in the user configuration file keeping the rest of it.
+func updateConfigFile(cfg *config.Core, key string, value any) error {
+	content, err := os.ReadFile(cfg.FS.ConfigFile)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	content, err = config.SetValue(content, key, value)
+	if errors.Is(err, config.ErrInvalidFile) {
+		return fmt.Errorf("%w, fix it with 'nao config edit'", err)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := config.Validate(cfg.FS, content); err != nil {
+		return err
+	}
+
+	return writeConfigFile(cfg, content)
+}
+
+func (c *ConfigCmd) Edit() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		content, err := os.ReadFile(c.config.FS.ConfigFile)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		if err := os.MkdirAll(c.config.FS.CacheDir, os.ModePerm); err != nil {
+			return err
+		}
+
+		// Keeps the extension to have syntax highlighting in the editor
+		filePath := filepath.Join(c.config.FS.CacheDir, "config.yml")
+
+		if err := os.WriteFile(filePath, content, internal.PermReadWrite); err != nil {
+			return err
+		}
+
+		defer os.Remove(filePath)
+
+		editor := c.editor
+		if editor == "" {
+			editor = c.config.Editor.Name
+		}
+
+		if editor == "" {
+			editor = internal.Nano
+		}
+
+		for {
+			if err := RunEditor(cmd.Context(), editor, filePath); err != nil {
+				return err
+			}
+
+			edited, err := os.ReadFile(filePath)
+			if err != nil {
+				return err
+			}
+
+			err = config.Validate(c.config.FS, edited)
+			if err == nil {
+				c.log.Trace().Msg("the edited configuration is valid, saving...")
+
+				return writeConfigFile(c.config, edited)
+			}
+
+			c.log.Err(err).Msg("the edited configuration is not valid")
+
+			ui.Error(err.Error())
+
+			var again bool
+
+			ui.YesOrNoPrompt(&again, "Do you want to edit it again? The changes will be discarded otherwise [y/N]")
+
+			if !again {
+				return errors.New("the configuration file was not modified")
+			}
+		}
+	}
+}
+
+func (c *ConfigCmd) Reset() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if !c.yes {
+			ui.YesOrNoPrompt(&c.yes, "Are you sure you want to restore the default configuration? [y/N]")
+		}
+
+		if !c.yes {
+			return nil
+		}
+
+		return writeConfigFile(c.config, config.DefaultFile)
+	}
+}
+
+// Returns the value of the key formatted in a single line.
+func configValue(m map[string]any, key string) string {
+	var value any = m
+
+	for _, part := range strings.Split(key, ".") {
+		section, ok := value.(map[string]any)
+		if !ok {
+			return ""
+		}
+
+		value = section[part]
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []any:
+		items := make([]string, len(v))
+
+		for i, item := range v {
+			// Like the custom themes
+			if section, ok := item.(map[string]any); ok && section["name"] != nil {
+				item = section["name"]
+			}
+
+			items[i] = fmt.Sprint(item)
+		}
+
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+
+	return fmt.Sprint(value)
+}
+
+func writeConfigFile(cfg *config.Core, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(cfg.FS.ConfigFile), os.ModePerm); err != nil {
+		return err
+	}
+
+	if !strings.HasSuffix(string(content), "\n") {
+		content = append(content, '\n')
+	}
+
+	return os.WriteFile(cfg.FS.ConfigFile, content, internal.PermReadWrite)
+}
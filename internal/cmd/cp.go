@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/luisnquin/nao/v3/internal/clipboard"
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/luisnquin/nao/v3/internal/secrets"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+// The hidden command that clears the clipboard in the background.
+const clearClipboardCmd = "clear-clipboard"
+
+// The clipboard is cleared after this duration when a secret is copied and
+// there's no other duration.
+const sensitiveClearAfter = 45 * time.Second
+
+type CpCmd struct {
+	*cobra.Command
+
+	log        *zerolog.Logger
+	config     *config.Core
+	data       *data.Buffer
+	clearAfter time.Duration
+}
+
+func BuildCp(log *zerolog.Logger, config *config.Core, data *data.Buffer) CpCmd {
+	c := CpCmd{
+		Command: &cobra.Command{
+			Use:               "cp [<id> | <tag>]",
+			Aliases:           []string{"yank"},
+			Short:             "Copy the content of a file to the clipboard",
+			Args:              cobra.MaximumNArgs(1),
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: KeyTagCompletions(data),
+		},
+		config: config,
+		data:   data,
+		log:    log,
+	}
+
+	c.RunE = c.Main()
+
+	log.Trace().Msg("the 'cp' command has been created")
+
+	c.Flags().DurationVar(&c.clearAfter, "clear-after", config.Clipboard.ClearAfter, "clear the clipboard after a duration like 30s, never if 0")
+
+	return c
+}
+
+func (c *CpCmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		var arg string
+		if len(args) == 1 {
+			arg = args[0]
+		}
+
+		key, err := SearchOrPick(c.config, c.data, arg, false)
+		if err != nil {
+			return err
+		}
+
+		nt, err := note.NewRepository(c.data).Get(key)
+		if err != nil {
+			return err
+		}
+
+		backend, err := clipboard.Detect(c.config.Clipboard.Backend)
+		if err != nil {
+			return err
+		}
+
+		c.log.Trace().Str("backend", backend.Name).Str("key", key).Msg("copying note to the clipboard...")
+
+		content, _, err := unlockNote(nt)
+		if err != nil {
+			return err
+		}
+
+		content = secrets.Reveal(content)
+
+		if err := backend.Copy(content); err != nil {
+			return err
+		}
+
+		clearAfter := c.clearAfter
+
+		// Secrets shouldn't stay in the clipboard unless it's explicitly requested
+		if clearAfter <= 0 && !cmd.Flags().Changed("clear-after") && (nt.Sensitive || nt.Locked || secrets.HasMarkers(content)) {
+			clearAfter = sensitiveClearAfter
+		}
+
+		if clearAfter <= 0 {
+			return nil
+		}
+
+		return clearClipboardLater(c.log, clearAfter, content)
+	}
+}
+
+// Starts a background process that clears the clipboard after the duration
+// if it still has the content. The hash of the content is written to its
+// standard input, the command line can be read by any user.
+func clearClipboardLater(log *zerolog.Logger, after time.Duration, content string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+
+	defer w.Close()
+
+	process := exec.Command(executable, clearClipboardCmd, after.String())
+	process.Stdin = r
+	// It survives the terminal, that would kill it before clearing the clipboard
+	process.SysProcAttr = detachedProcAttr()
+
+	err = process.Start()
+	r.Close()
+
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256([]byte(content))
+
+	if _, err := io.WriteString(w, hex.EncodeToString(sum[:])); err != nil {
+		return err
+	}
+
+	log.Trace().Int("pid", process.Process.Pid).Dur("after", after).Msg("the clipboard will be cleared")
+
+	return process.Process.Release()
+}
+
+func BuildClearClipboard(log *zerolog.Logger, config *config.Core) *cobra.Command {
+	log.Trace().Msgf("the '%s' command has been created", clearClipboardCmd)
+
+	return &cobra.Command{
+		Use:           clearClipboardCmd + " <duration>",
+		Hidden:        true,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			after, err := time.ParseDuration(args[0])
+			if err != nil {
+				return err
+			}
+
+			// The SHA-256 of the copied content
+			hash, err := io.ReadAll(io.LimitReader(cmd.InOrStdin(), sha256.Size*2))
+			if err != nil {
+				return err
+			}
+
+			backend, err := clipboard.Detect(config.Clipboard.Backend)
+			if err != nil {
+				return err
+			}
+
+			time.Sleep(after)
+
+			// The terminal clipboard can't be read to check it
+			if content, err := backend.Paste(); err == nil {
+				sum := sha256.Sum256([]byte(content))
+
+				if hex.EncodeToString(sum[:]) != string(hash) {
+					log.Trace().Msg("the clipboard has changed, it will not be cleared")
+
+					return nil
+				}
+			}
+
+			log.Trace().Str("backend", backend.Name).Msg("clearing the clipboard...")
+
+			return backend.Clear()
+		},
+	}
+}
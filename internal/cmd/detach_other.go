@@ -0,0 +1,9 @@
+//go:build windows || plan9 || js
+
+package cmd
+
+import "syscall"
+
+func detachedProcAttr() *syscall.SysProcAttr {
+	return nil
+}
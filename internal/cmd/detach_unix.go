@@ -0,0 +1,10 @@
+//go:build !windows && !plan9 && !js
+
+package cmd
+
+import "syscall"
+
+// Starts the process in its own session, so it's not killed along with the terminal.
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/format"
+	"github.com/luisnquin/nao/v3/internal/models"
+	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/luisnquin/nao/v3/internal/utils"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+// The exit code of 'nao due --exit-code' when there are overdue notes.
+const dueExitCode = 2
+
+type DueCmd struct {
+	*cobra.Command
+
+	log      *zerolog.Logger
+	config   *config.Core
+	data     *data.Buffer
+	overdue  bool
+	within   string
+	quiet    bool
+	exitCode bool
+	format   string
+}
+
+// The columns displayed by the due command.
+var dueColumns = []string{"ID", "TAG", "DUE", "PREVIEW"}
+
+func BuildDue(log *zerolog.Logger, config *config.Core, data *data.Buffer) DueCmd {
+	c := DueCmd{
+		Command: &cobra.Command{
+			Use:               "due",
+			Short:             "See the overdue and upcoming notes, from the soonest",
+			Args:              cobra.NoArgs,
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: cobra.NoFileCompletions,
+		},
+		config: config,
+		data:   data,
+		log:    log,
+	}
+
+	c.RunE = c.Main()
+
+	log.Trace().Msg("the 'due' command has been created")
+
+	flags := c.Flags()
+	flags.BoolVar(&c.overdue, "overdue", false, "only the overdue notes")
+	flags.StringVar(&c.within, "within", "", "only the notes due within a duration(12h, 3d, 2w) or until a date like 'friday'")
+	flags.BoolVarP(&c.quiet, "quiet", "q", false, "only display file ID's")
+	flags.BoolVar(&c.exitCode, "exit-code", false, fmt.Sprintf("exit with %d if there are overdue notes", dueExitCode))
+	flags.StringVar(&c.format, "format", "", "raw output in "+strings.Join(format.Kinds(), "|")+" or a Go template like '{{.Tag}}\\t{{.DueAt}}'")
+
+	return c
+}
+
+func (c *DueCmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		now := time.Now()
+
+		query := note.Query{SortBy: note.SortDue, Due: true}
+
+		switch {
+		case c.overdue && c.within != "":
+			return fmt.Errorf("only use one of --overdue and --within")
+		case c.overdue:
+			query.DueUntil = now
+		case c.within != "":
+			until, err := utils.ParseDue(c.within, now)
+			if err != nil {
+				return err
+			}
+
+			query.DueUntil = until
+		}
+
+		c.log.Trace().Interface("query", query).Msg("filtering and sorting due notes")
+
+		notes := query.Apply(note.NewRepository(c.data).Slice())
+
+		if err := c.print(notes); err != nil {
+			return err
+		}
+
+		if c.exitCode && len(notes) > 0 && notes[0].IsOverdue(now) {
+			c.log.Trace().Msg("there are overdue notes")
+
+			return ExitCodeError(dueExitCode)
+		}
+
+		return nil
+	}
+}
+
+func (c *DueCmd) print(notes []models.Note) error {
+	keySize := lsKeySize(c.config)
+
+	switch {
+	case c.quiet:
+		for _, n := range notes {
+			fmt.Fprintln(os.Stdout, n.Key[:keySize])
+		}
+
+		return nil
+
+	case c.format != "":
+		fields := make([]string, len(dueColumns))
+
+		for i, column := range dueColumns {
+			fields[i] = lsColumnFields[column]
+		}
+
+		return format.Write(os.Stdout, c.format, notes, fields)
+	}
+
+	if len(notes) == 0 {
+		c.log.Trace().Msg("no due notes")
+
+		return nil
+	}
+
+	// The table uses the columns of the configuration
+	due := *c.config
+	due.Command.Ls.Columns = dueColumns
+
+	t := lsTable(&due, notes, lsColumnPrinters(&due), keySize, false)
+	t.SetOutputMirror(os.Stdout)
+	t.Render()
+
+	return nil
+}
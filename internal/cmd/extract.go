@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/luisnquin/nao/v3/internal"
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/luisnquin/nao/v3/internal/utils"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+type ExtractCmd struct {
+	*cobra.Command
+
+	log    *zerolog.Logger
+	config *config.Core
+	data   *data.Buffer
+	output string
+	force  bool
+}
+
+func BuildExtract(log *zerolog.Logger, config *config.Core, data *data.Buffer) ExtractCmd {
+	c := ExtractCmd{
+		Command: &cobra.Command{
+			Use:               "extract <id> | <tag> <name>",
+			Short:             "Write an attachment of a note to a file",
+			Args:              cobra.ExactArgs(2),
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: attachmentCompletions(data),
+		},
+		config: config,
+		data:   data,
+		log:    log,
+	}
+
+	c.RunE = c.Main()
+
+	log.Trace().Msg("the 'extract' command has been created")
+
+	flags := c.Flags()
+	flags.StringVarP(&c.output, "output", "o", "", "the path of the file, by default the name of the attachment, '-' for the standard output")
+	flags.BoolVarP(&c.force, "force", "f", false, "overwrite the file if it already exists")
+
+	return c
+}
+
+func (c *ExtractCmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		key, err := SearchOrPick(c.config, c.data, args[0], false)
+		if err != nil {
+			return err
+		}
+
+		nt, err := note.NewRepository(c.data).Peek(key)
+		if err != nil {
+			return err
+		}
+
+		// The attachments are as private as the content of the note
+		if _, _, err := unlockNote(nt); err != nil {
+			return err
+		}
+
+		attachment, ok := nt.Attachment(args[1])
+		if !ok {
+			return fmt.Errorf("%w: %s", note.ErrAttachmentNotFound, args[1])
+		}
+
+		content, err := c.data.Blobs().Get(attachment.Hash)
+		if err != nil {
+			return err
+		}
+
+		output := c.output
+		if output == "" {
+			output = attachment.Name
+		}
+
+		if output == "-" {
+			_, err := os.Stdout.Write(content)
+
+			return err
+		}
+
+		if utils.FileExists(output) && !c.force {
+			return fmt.Errorf("file '%s' already exists, use --force to overwrite it", output)
+		}
+
+		c.log.Trace().Str("name", attachment.Name).Str("output", output).Msg("extracting attachment...")
+
+		return os.WriteFile(output, content, internal.PermReadWrite)
+	}
+}
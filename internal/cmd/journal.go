@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/models"
+	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/luisnquin/nao/v3/internal/utils"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+// The tag of the journal entries when there's no pattern in the configuration file.
+const journalDefaultPattern = "journal-2006-01-02"
+
+// The columns displayed by 'nao journal ls'.
+var journalColumns = []string{"ID", "TAG", "WORDS", "TIME SPENT", "PREVIEW"}
+
+type JournalCmd struct {
+	*cobra.Command
+
+	log    *zerolog.Logger
+	config *config.Core
+	data   *data.Buffer
+	editor string
+	month  string
+	quiet  bool
+}
+
+func BuildJournal(log *zerolog.Logger, config *config.Core, data *data.Buffer) JournalCmd {
+	c := newJournalCmd(log, config, data, &cobra.Command{
+		Use:   "journal [<date>]",
+		Short: "Open or create the journal entry of a date like 2006-01-02, yesterday or 3d",
+		Args:  cobra.MaximumNArgs(1),
+	})
+
+	ls := &cobra.Command{
+		Use:               "ls",
+		Short:             "See a list of the journal entries",
+		Args:              cobra.NoArgs,
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE:              c.Ls(),
+	}
+
+	ls.Flags().StringVar(&c.month, "month", "", "only the entries of a month, like 2006-01")
+	ls.Flags().BoolVarP(&c.quiet, "quiet", "q", false, "only display file ID's")
+
+	c.AddCommand(ls)
+
+	return c
+}
+
+func BuildToday(log *zerolog.Logger, config *config.Core, data *data.Buffer) JournalCmd {
+	return newJournalCmd(log, config, data, &cobra.Command{
+		Use:   "today",
+		Short: "Open or create the journal entry of today",
+		Args:  cobra.NoArgs,
+	})
+}
+
+func newJournalCmd(log *zerolog.Logger, config *config.Core, data *data.Buffer, command *cobra.Command) JournalCmd {
+	command.SilenceUsage = true
+	command.SilenceErrors = true
+	command.ValidArgsFunction = cobra.NoFileCompletions
+
+	c := JournalCmd{
+		Command: command,
+		config:  config,
+		data:    data,
+		log:     log,
+	}
+
+	c.RunE = c.Main()
+
+	log.Trace().Msgf("the '%s' command has been created", c.Name())
+
+	c.Flags().StringVar(&c.editor, "editor", "", "change the default code editor (ignoring configuration file)")
+
+	return c
+}
+
+func (c *JournalCmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		date := time.Now()
+
+		if len(args) == 1 {
+			var err error
+
+			date, err = parseJournalDate(args[0], date)
+			if err != nil {
+				return err
+			}
+		}
+
+		pattern, err := c.pattern()
+		if err != nil {
+			return err
+		}
+
+		tag := date.Format(pattern)
+
+		c.log.Trace().Str("tag", tag).Msg("looking for the journal entry...")
+
+		notesRepo := note.NewRepository(c.data)
+
+		for _, n := range notesRepo.SliceOf(note.AllNotes) {
+			if n.Tag != tag {
+				continue
+			}
+
+			nt, err := notesRepo.Get(n.Key)
+			if err != nil {
+				return err
+			}
+
+			mod := ModCmd{log: c.log, config: c.config, data: c.data, editor: c.editor}
+
+			return mod.edit(cmd.Context(), nt)
+		}
+
+		// Otherwise the content would be lost after closing the editor
+		if err := note.NewTagger(c.data).IsValid(tag); err != nil {
+			return fmt.Errorf("the journal pattern produces the tag '%s': %w", tag, err)
+		}
+
+		seed, err := c.seed(date)
+		if err != nil {
+			return err
+		}
+
+		c.log.Trace().Str("tag", tag).Msg("creating the journal entry...")
+
+		create := NewCmd{log: c.log, config: c.config, data: c.data, editor: c.editor, tag: tag}
+
+		return create.create(cmd.Context(), seed)
+	}
+}
+
+func (c *JournalCmd) Ls() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		pattern, err := c.pattern()
+		if err != nil {
+			return err
+		}
+
+		var month time.Time
+
+		if c.month != "" {
+			month, err = time.ParseInLocation("2006-01", c.month, time.Local)
+			if err != nil {
+				return fmt.Errorf("invalid month '%s', expected something like 2006-01", c.month)
+			}
+		}
+
+		type entry struct {
+			models.Note
+			date time.Time
+		}
+
+		var entries []entry
+
+		// Like the entries opened by 'nao journal <date>', the archived ones too
+		for _, n := range note.NewRepository(c.data).SliceOf(note.AllNotes) {
+			date, err := time.ParseInLocation(pattern, n.Tag, time.Local)
+			if err != nil {
+				continue
+			}
+
+			if !month.IsZero() && (date.Year() != month.Year() || date.Month() != month.Month()) {
+				continue
+			}
+
+			entries = append(entries, entry{Note: n, date: date})
+		}
+
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].date.After(entries[j].date)
+		})
+
+		notes := make([]models.Note, len(entries))
+
+		for i, e := range entries {
+			notes[i] = e.Note
+		}
+
+		keySize := lsKeySize(c.config)
+
+		if c.quiet {
+			for _, n := range notes {
+				fmt.Fprintln(os.Stdout, n.Key[:keySize])
+			}
+
+			return nil
+		}
+
+		if len(notes) == 0 {
+			c.log.Trace().Msg("no journal entries")
+
+			return nil
+		}
+
+		// The table uses the columns of the configuration
+		journal := *c.config
+		journal.Command.Ls.Columns = journalColumns
+
+		t := lsTable(&journal, notes, lsColumnPrinters(&journal), keySize, false)
+		t.SetOutputMirror(os.Stdout)
+		t.Render()
+
+		return nil
+	}
+}
+
+// Returns the pattern of the tags, which must change from one day to another.
+func (c *JournalCmd) pattern() (string, error) {
+	pattern := c.config.Command.Journal.Pattern
+	if pattern == "" {
+		pattern = journalDefaultPattern
+	}
+
+	day := time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	if day.Format(pattern) == day.AddDate(0, 0, 1).Format(pattern) {
+		return "", fmt.Errorf("the journal pattern '%s' must include the day, like %s", pattern, journalDefaultPattern)
+	}
+
+	return pattern, nil
+}
+
+// Returns the content of the template of the configuration, if any,
+// executed with the date of the entry.
+func (c *JournalCmd) seed(date time.Time) (string, error) {
+	ref := c.config.Command.Journal.Template
+	if ref == "" {
+		return "", nil
+	}
+
+	key, err := note.Resolve(ref, c.data)
+	if err != nil {
+		return "", fmt.Errorf("journal template '%s': %w", ref, err)
+	}
+
+	nt, err := note.NewRepository(c.data).Peek(key)
+	if err != nil {
+		return "", err
+	}
+
+	if nt.Locked {
+		return "", fmt.Errorf("journal template '%s': %w", ref, note.ErrNoteLocked)
+	}
+
+	tmpl, err := template.New(nt.Tag).Parse(nt.Content)
+	if err != nil {
+		return "", fmt.Errorf("journal template '%s': %w", ref, err)
+	}
+
+	var b bytes.Buffer
+
+	if err := tmpl.Execute(&b, struct{ Date time.Time }{date}); err != nil {
+		return "", fmt.Errorf("journal template '%s': %w", ref, err)
+	}
+
+	return b.String(), nil
+}
+
+// Parses a date like 2006-01-02, today, yesterday or a duration ago like 3d.
+func parseJournalDate(s string, now time.Time) (time.Time, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "today":
+		return now, nil
+	case "yesterday":
+		return now.AddDate(0, 0, -1), nil
+	case "tomorrow":
+		return now.AddDate(0, 0, 1), nil
+	}
+
+	return utils.ParseTime(s, now)
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/share"
+	"github.com/luisnquin/nao/v3/internal/ui"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+type KeysCmd struct {
+	*cobra.Command
+
+	log    *zerolog.Logger
+	config *config.Core
+	keys   share.Keys
+}
+
+func BuildKeys(log *zerolog.Logger, config *config.Core) KeysCmd {
+	c := KeysCmd{
+		Command: &cobra.Command{
+			Use:               "keys",
+			Short:             "Display your public key, to receive the files shared with 'nao share'",
+			Args:              cobra.NoArgs,
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: cobra.NoFileCompletions,
+		},
+		config: config,
+		keys:   share.Keys{Dir: config.FS.KeysDir},
+		log:    log,
+	}
+
+	c.RunE = c.Main()
+
+	c.AddCommand(
+		&cobra.Command{
+			Use:               "generate",
+			Short:             "Generate your key pair, stored in the configuration directory",
+			Args:              cobra.NoArgs,
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: cobra.NoFileCompletions,
+			RunE:              c.Generate(),
+		},
+		&cobra.Command{
+			Use:               "add <name> <public key>",
+			Short:             "Save the public key of someone, to share files with 'nao share --to <name>'",
+			Args:              cobra.ExactArgs(2),
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: cobra.NoFileCompletions,
+			RunE:              c.Add(),
+		},
+		&cobra.Command{
+			Use:               "ls",
+			Short:             "See the saved public keys",
+			Args:              cobra.NoArgs,
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: cobra.NoFileCompletions,
+			RunE:              c.Ls(),
+		},
+		&cobra.Command{
+			Use:               "rm <name>",
+			Short:             "Remove a saved public key",
+			Args:              cobra.ExactArgs(1),
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: contactCompletions(c.keys),
+			RunE:              c.Rm(),
+		},
+	)
+
+	log.Trace().Msg("the 'keys' command has been created")
+
+	return c
+}
+
+func (c *KeysCmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		identity, err := c.keys.Identity()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stdout, identity.Recipient())
+
+		return nil
+	}
+}
+
+func (c *KeysCmd) Generate() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		c.log.Trace().Str("dir", c.keys.Dir).Msg("generating key pair...")
+
+		identity, err := c.keys.Generate()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stdout, identity.Recipient())
+
+		return nil
+	}
+}
+
+func (c *KeysCmd) Add() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		c.log.Trace().Str("name", args[0]).Msg("saving public key...")
+
+		return c.keys.AddContact(args[0], args[1])
+	}
+}
+
+func (c *KeysCmd) Ls() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		contacts, err := c.keys.Contacts()
+		if err != nil {
+			return err
+		}
+
+		name := colorOrNop(c.config.Element("keys", "name", ui.RoleTag).Color, false)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+		for _, contact := range contacts {
+			fmt.Fprintf(w, "%s\t%s\n", name.Sprint(contact.Name), contact.PublicKey)
+		}
+
+		return w.Flush()
+	}
+}
+
+func (c *KeysCmd) Rm() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		c.log.Trace().Str("name", args[0]).Msg("removing public key...")
+
+		return c.keys.RemoveContact(args[0])
+	}
+}
+
+func contactCompletions(keys share.Keys) func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		contacts, _ := keys.Contacts()
+
+		names := make([]string, len(contacts))
+
+		for i, contact := range contacts {
+			names[i] = contact.Name + "\t" + contact.PublicKey
+		}
+
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/models"
+	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/luisnquin/nao/v3/internal/security"
+	"github.com/luisnquin/nao/v3/internal/tui"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+type LockCmd struct {
+	*cobra.Command
+
+	log    *zerolog.Logger
+	config *config.Core
+	data   *data.Buffer
+	unlock bool
+}
+
+func BuildLock(log *zerolog.Logger, config *config.Core, data *data.Buffer) LockCmd {
+	return newLockCmd(log, config, data, &cobra.Command{
+		Use:   "lock <id> | <tag>...",
+		Short: "Encrypt files with their own passphrase, required to read or modify them",
+	}, false)
+}
+
+func BuildUnlock(log *zerolog.Logger, config *config.Core, data *data.Buffer) LockCmd {
+	return newLockCmd(log, config, data, &cobra.Command{
+		Use:   "unlock <id> | <tag>...",
+		Short: "Remove the passphrase of locked files",
+	}, true)
+}
+
+func newLockCmd(log *zerolog.Logger, config *config.Core, data *data.Buffer, command *cobra.Command, unlock bool) LockCmd {
+	command.Args = cobra.MinimumNArgs(1)
+	command.SilenceUsage = true
+	command.SilenceErrors = true
+	command.ValidArgsFunction = KeyTagCompletions(data)
+
+	c := LockCmd{
+		Command: command,
+		config:  config,
+		data:    data,
+		log:     log,
+		unlock:  unlock,
+	}
+
+	c.RunE = c.Main()
+
+	log.Trace().Msgf("the '%s' command has been created", c.Name())
+
+	return c
+}
+
+func (c *LockCmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		notesRepo := note.NewRepository(c.data)
+
+		notes := make([]models.Note, 0, len(args))
+
+		for _, arg := range args {
+			key, err := SearchOrPick(c.config, c.data, arg, true)
+			if err != nil {
+				return err
+			}
+
+			nt, err := notesRepo.Peek(key)
+			if err != nil {
+				return err
+			}
+
+			if c.unlock == nt.Locked {
+				notes = append(notes, nt)
+
+				continue
+			}
+
+			if c.unlock {
+				return fmt.Errorf("%s: %w", nt.Tag, note.ErrNoteNotLocked)
+			}
+
+			return fmt.Errorf("%s: note already locked", nt.Tag)
+		}
+
+		if c.unlock {
+			for _, nt := range notes {
+				content, _, err := unlockNote(nt)
+				if err != nil {
+					return err
+				}
+
+				c.log.Trace().Str("key", nt.Key).Msg("unlocking note...")
+
+				if err := notesRepo.Update(nt.Key, note.WithoutLock(content)); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+
+		passphrase, err := security.NewPassphrase(tui.ReadPassword, "New passphrase: ")
+		if err != nil {
+			return err
+		}
+
+		for _, nt := range notes {
+			encrypted, err := note.Lock(nt.Content, passphrase)
+			if err != nil {
+				return err
+			}
+
+			c.log.Trace().Str("key", nt.Key).Msg("locking note...")
+
+			if err := notesRepo.Update(nt.Key, note.WithLock(encrypted)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// Returns the plain content of the note and the passphrase typed to decrypt it,
+// if the note is locked.
+func unlockNote(nt models.Note) (string, string, error) {
+	if !nt.Locked {
+		return nt.Content, "", nil
+	}
+
+	passphrase, err := tui.ReadPassword(fmt.Sprintf("Passphrase of %s: ", nt.Tag))
+	if err != nil {
+		return "", "", err
+	}
+
+	content, err := note.Unlock(nt, passphrase)
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", nt.Tag, err)
+	}
+
+	return content, passphrase, nil
+}
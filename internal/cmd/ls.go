@@ -4,17 +4,18 @@ import (
 	"encoding/csv"
 	"fmt"
 	"os"
-	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/goccy/go-json"
 	"github.com/gookit/color"
 	"github.com/jedib0t/go-pretty/table"
 	"github.com/jedib0t/go-pretty/text"
-	"github.com/luisnquin/nao/v3/internal"
 	"github.com/luisnquin/nao/v3/internal/config"
 	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/format"
+	"github.com/luisnquin/nao/v3/internal/models"
 	"github.com/luisnquin/nao/v3/internal/note"
 	"github.com/luisnquin/nao/v3/internal/ui"
 	"github.com/luisnquin/nao/v3/internal/utils"
@@ -31,6 +32,16 @@ type LsCmd struct {
 	data        *data.Buffer
 	Quiet, Long bool
 	json, csv   bool
+
+	sortBy, dateField string
+	since, until      string
+	minSize, maxSize  string
+	tagPrefix, format string
+	columns           []string
+	limit             int
+	reverse           bool
+
+	archived, all, favorites bool
 }
 
 func BuildLs(log *zerolog.Logger, config *config.Core, data *data.Buffer) LsCmd {
@@ -55,37 +66,179 @@ func BuildLs(log *zerolog.Logger, config *config.Core, data *data.Buffer) LsCmd
 	flags := c.Flags()
 	flags.BoolVarP(&c.Long, "long", "l", false, "display the content as long as possible")
 	flags.BoolVarP(&c.Quiet, "quiet", "q", false, "only display file ID's")
-	flags.BoolVar(&c.csv, "csv", false, "the displayed output will be in CSV format")
-	flags.BoolVar(&c.json, "json", false, "the displayed output will be in JSON format")
+	flags.BoolVar(&c.csv, "csv", false, "the displayed output will be in CSV format, with human-readable values")
+	flags.BoolVar(&c.json, "json", false, "the displayed output will be in JSON format, with human-readable values")
+	flags.StringVar(&c.format, "format", "", "raw output in "+strings.Join(format.Kinds(), "|")+" or a Go template like '{{.Tag}}\\t{{.LastUpdate}}'")
+	flags.StringVar(&c.sortBy, "sort", note.SortUpdated, "sort by "+strings.Join(note.SortFields(), "|")+", tags in alphabetical order and the rest from newest or biggest")
+	flags.BoolVar(&c.reverse, "reverse", false, "reverse the sort order")
+	flags.IntVar(&c.limit, "limit", 0, "display at most N notes")
+	flags.StringVar(&c.since, "since", "", "only notes updated since a date(2006-01-02) or a duration(12h, 3d, 2w) ago")
+	flags.StringVar(&c.until, "until", "", "only notes updated until a date(2006-01-02) or a duration(12h, 3d, 2w) ago")
+	flags.StringVar(&c.dateField, "date", note.SortUpdated, "the date used by --since and --until, created|updated")
+	flags.StringVar(&c.tagPrefix, "tag-prefix", "", "only notes whose tag starts with the prefix")
+	flags.StringVar(&c.minSize, "min-size", "", "only notes of at least the size(512B, 10KB, 1.5MB)")
+	flags.StringVar(&c.maxSize, "max-size", "", "only notes of at most the size(512B, 10KB, 1.5MB)")
+	flags.BoolVar(&c.archived, "archived", false, "only the archived notes")
+	flags.BoolVarP(&c.all, "all", "a", false, "include the archived notes")
+	flags.BoolVar(&c.favorites, "favorites", false, "only the favorite notes")
+
+	c.RegisterFlagCompletionFunc("sort", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return note.SortFields(), cobra.ShellCompDirectiveNoFileComp
+	})
+
+	flags.StringSliceVar(&c.columns, "columns", nil, "comma-separated columns to display, overrides the configuration file")
+
+	c.RegisterFlagCompletionFunc("columns", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		completions := make([]string, len(lsAllColumns))
+
+		for i, column := range lsAllColumns {
+			completions[i] = strings.ReplaceAll(strings.ToLower(column), " ", "-")
+		}
+
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	c.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return format.Kinds(), cobra.ShellCompDirectiveNoFileComp
+	})
+
+	c.RegisterFlagCompletionFunc("date", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{note.SortCreated, note.SortUpdated}, cobra.ShellCompDirectiveNoFileComp
+	})
 
 	return c
 }
 
+// Builds the query from the flags.
+func (c *LsCmd) query() (note.Query, error) {
+	q := note.Query{
+		SortBy:      c.sortBy,
+		Reverse:     c.reverse,
+		Limit:       c.limit,
+		TagPrefix:   c.tagPrefix,
+		Favorites:   c.favorites,
+		PinnedFirst: true,
+	}
+
+	if !utils.Contains(note.SortFields(), c.sortBy) {
+		return q, fmt.Errorf("unknown sort field '%s', expected one of: %s", c.sortBy, strings.Join(note.SortFields(), ", "))
+	}
+
+	switch c.dateField {
+	case note.SortCreated:
+		q.ByCreation = true
+	case note.SortUpdated:
+	default:
+		return q, fmt.Errorf("unknown date '%s', expected %s or %s", c.dateField, note.SortCreated, note.SortUpdated)
+	}
+
+	if c.limit < 0 {
+		return q, fmt.Errorf("the limit can't be negative")
+	}
+
+	now := time.Now()
+
+	var err error
+
+	if c.since != "" {
+		if q.Since, err = utils.ParseTime(c.since, now); err != nil {
+			return q, err
+		}
+	}
+
+	if c.until != "" {
+		if q.Until, err = utils.ParseTime(c.until, now); err != nil {
+			return q, err
+		}
+
+		// The whole day is included
+		if len(strings.TrimSpace(c.until)) == len("2006-01-02") {
+			q.Until = q.Until.Add(24*time.Hour - time.Nanosecond)
+		}
+	}
+
+	if c.minSize != "" {
+		if q.MinSize, err = utils.ParseSize(c.minSize); err != nil {
+			return q, err
+		}
+	}
+
+	if c.maxSize != "" {
+		if q.MaxSize, err = utils.ParseSize(c.maxSize); err != nil {
+			return q, err
+		}
+	}
+
+	return q, nil
+}
+
 func (c *LsCmd) Main() cobra.PositionalArgs {
 	return func(cmd *cobra.Command, args []string) error {
-		if c.json && c.csv {
+		if (c.json && c.csv) || (c.format != "" && (c.json || c.csv || c.Quiet)) {
 			c.log.Error().Msg("both json and csv formats were passed in the same call")
 
 			return fmt.Errorf("only use a single format")
 		}
 
+		query, err := c.query()
+		if err != nil {
+			return err
+		}
+
 		notesRepo := note.NewRepository(c.data)
 
-		keySize := 10
+		keySize := lsKeySize(c.config)
+
+		c.log.Trace().Int("key size", keySize).Send()
+
+		c.log.Trace().Msg("fetching notes from store")
+
+		c.log.Trace().Interface("query", query).Msg("filtering and sorting notes")
+
+		visibility := note.Unarchived
 
-		if c.config.Command.Ls.KeySize > 2 && c.config.Command.Ls.KeySize < 33 {
-			keySize = c.config.Command.Ls.KeySize
+		switch {
+		case c.archived && c.all:
+			return fmt.Errorf("only use one of --archived and --all")
+		case c.archived:
+			visibility = note.OnlyArchived
+		case c.all:
+			visibility = note.AllNotes
 		}
 
-		c.log.Trace().Int("key size", keySize).Send()
+		notes := query.Apply(notesRepo.SliceOf(visibility))
+
+		columns, err := lsSelectedColumns(c.config, c.columns)
+		if err != nil {
+			return err
+		}
+
+		c.config.Command.Ls.Columns = columns
+
+		c.log.Trace().Strs("ls columns", columns).Send()
+
+		if c.format != "" {
+			c.log.Trace().Str("format", c.format).Msg("writing notes with custom format...")
+
+			fields := make([]string, len(columns))
+
+			for i, column := range columns {
+				fields[i] = lsColumnFields[column]
+			}
+
+			return format.Write(os.Stdout, c.format, notes, fields)
+		}
 
 		if c.Quiet {
 			c.log.Trace().Msg("listing all the note keys in quiet mode...")
-			keys := notesRepo.AllKeys()
 
-			if !c.Long {
-				for i, key := range keys {
-					keys[i] = key[:keySize]
+			keys := make([]string, len(notes))
+
+			for i, n := range notes {
+				keys[i] = n.Key
+
+				if !c.Long {
+					keys[i] = n.Key[:keySize]
 				}
 			}
 
@@ -100,41 +253,11 @@ func (c *LsCmd) Main() cobra.PositionalArgs {
 			return nil
 		}
 
-		if len(c.config.Command.Ls.Columns) == 0 {
-			c.config.Command.Ls.Columns = []string{
-				"ID", "TAG", "LAST UPDATE", "SIZE", "TIME SPENT", "VERSION",
-			}
-		} // else {
-		//	for i, column := range c.config.Command.Ls.Columns {
-		//		c.config.Command.Ls.Columns[i] = strings.ToUpper(strings.TrimSpace(column))
-		//	}
-		//}
-
-		c.log.Trace().Strs("ls columns", c.config.Command.Ls.Columns)
-
-		c.log.Trace().Msg("fetching notes from store")
-
-		notes := notesRepo.Slice()
-
 		c.log.Trace().Msg("loading printers faces of all available columns")
 
-		colors := map[string]color.PrinterFace{
-			"ID":            c.ColorOrNop(c.config.Colors.Three),
-			"TAG":           c.ColorOrNop(c.config.Colors.Four),
-			"SIZE":          c.ColorOrNop(c.config.Colors.Five),
-			"LAST UPDATE":   c.ColorOrNop(c.config.Colors.Six),
-			"CREATION DATE": c.ColorOrNop(c.config.Colors.Seven),
-			"TIME SPENT":    c.ColorOrNop(c.config.Colors.Eight),
-			"VERSION":       c.ColorOrNop(c.config.Colors.Nine),
-		}
-
-		c.log.Trace().Msg("sorting notes by last update")
+		colors := lsColumnPrinters(c.config)
 
-		sort.SliceStable(notes, func(i, j int) bool {
-			return notes[i].LastUpdate.After(notes[j].LastUpdate)
-		})
-
-		rawHeader := []string{"ID", "TAG", "SIZE", "LAST UPDATE", "CREATION DATE", "TIME SPENT", "VERSION"}
+		rawHeader := append([]string{}, columns...)
 		rawRows := make([][]string, len(notes))
 
 		for i, n := range notes {
@@ -142,13 +265,14 @@ func (c *LsCmd) Main() cobra.PositionalArgs {
 				n.Key = n.Key[:keySize]
 			}
 
-			rawRows[i] = []string{
-				n.Key, n.Tag, n.ReadableSize(),
-				timeago.English.Format(n.LastUpdate),
-				timeago.English.Format(n.CreatedAt),
-				n.TimeSpent.Round(time.Second).String(),
-				strconv.Itoa(n.Version),
+			values := lsColumnValues(n)
+			row := make([]string, len(columns))
+
+			for j, column := range columns {
+				row[j] = values[column]
 			}
+
+			rawRows[i] = row
 		}
 
 		if c.csv {
@@ -184,81 +308,217 @@ func (c *LsCmd) Main() cobra.PositionalArgs {
 			return json.NewEncoder(os.Stdout).Encode(content)
 		}
 
-		c.log.Trace().Msg("creating table rows from notes, printer faces and configuration")
+		c.log.Trace().Msg("creating table from notes, printer faces and configuration")
 
-		rows := make([]table.Row, len(notes))
+		t := lsTable(c.config, notes, colors, keySize, c.Long)
+		t.SetOutputMirror(os.Stdout)
 
-		for i, n := range notes {
-			if !c.Long {
-				n.Key = n.Key[:keySize]
-			}
+		c.log.Trace().Msg("rendering table...")
 
-			noteMap := map[string]any{
-				"ID":            n.Key,
-				"TAG":           n.Tag,
-				"SIZE":          n.ReadableSize(),
-				"LAST UPDATE":   timeago.English.Format(n.LastUpdate),
-				"CREATION DATE": timeago.English.Format(n.CreatedAt),
-				"TIME SPENT":    n.TimeSpent.Round(time.Second),
-				"VERSION":       n.Version,
-			}
+		t.Render()
 
-			for k, v := range noteMap {
-				if !utils.Contains(c.config.Command.Ls.Columns, k) {
-					delete(noteMap, k)
-				} else {
-					noteMap[k] = colors[k].Sprint(v)
-				}
-			}
+		return nil
+	}
+}
+
+// Creates the table of notes with the configured columns and colors.
+func lsTable(config *config.Core, notes []models.Note, colors map[string]color.PrinterFace, keySize int, long bool) table.Writer {
+	columns := config.Command.Ls.Columns
+
+	rows := make([]table.Row, len(notes))
 
-			row := make(table.Row, len(c.config.Command.Ls.Columns))
+	for i, n := range notes {
+		if !long {
+			n.Key = n.Key[:keySize]
+		}
+
+		noteMap := lsColumnValues(n)
 
-			for j, column := range c.config.Command.Ls.Columns {
-				row[j] = noteMap[column]
+		row := make(table.Row, len(columns))
+
+		for j, column := range columns {
+			if printer, ok := colors[column]; ok {
+				row[j] = printer.Sprint(noteMap[column])
 			}
+		}
 
-			rows[i] = row
+		rows[i] = row
+	}
+
+	// We prepare the header and rows
+	header := make(table.Row, len(columns))
+	headerColorizer := colorOrNop(config.Element("ls", "header", ui.RoleHeader).Color, config.Command.Ls.NoColor)
+
+	for i, column := range columns {
+		if alias := lsElement(config, column).Alias; alias != "" {
+			column = alias
 		}
 
-		c.log.Trace().Msg("creating table header")
+		header[i] = headerColorizer.Sprint(column)
+	}
+
+	// Table build and render
+	t := table.NewWriter()
+	t.AppendHeader(header)
+	t.AppendRows(rows)
+	t.SetStyle(table.Style{
+		Box: table.StyleBoxDefault,
+		Format: table.FormatOptions{
+			Footer: text.FormatUpper,
+			Header: text.FormatTitle,
+			Row:    text.FormatDefault,
+		},
+		Options: table.OptionsNoBordersAndSeparators,
+	})
+
+	return t
+}
+
+// The columns displayed when there's nothing in the configuration file.
+var lsDefaultColumns = []string{"ID", "TAG", "LAST UPDATE", "CREATION DATE", "SIZE", "TIME SPENT", "VERSION"}
+
+// Every available column, in the order of the completions.
+var lsAllColumns = []string{
+	"ID", "TAG", "SIZE", "LAST UPDATE", "CREATION DATE", "TIME SPENT",
+	"VERSION", "PICKS", "LAST ACCESS", "LINES", "WORDS", "PREVIEW", "STATE", "DUE", "ATTACHMENTS",
+}
+
+// The field of the raw formats of every available column.
+var lsColumnFields = map[string]string{
+	"ID":            format.FieldKey,
+	"TAG":           format.FieldTag,
+	"SIZE":          format.FieldSize,
+	"LAST UPDATE":   format.FieldLastUpdate,
+	"CREATION DATE": format.FieldCreatedAt,
+	"TIME SPENT":    format.FieldTimeSpent,
+	"VERSION":       format.FieldVersion,
+	"PICKS":         format.FieldPicks,
+	"LAST ACCESS":   format.FieldAccessedAt,
+	"LINES":         format.FieldLines,
+	"WORDS":         format.FieldWords,
+	"PREVIEW":       format.FieldPreview,
+	"STATE":         format.FieldState,
+	"DUE":           format.FieldDueAt,
+	"ATTACHMENTS":   format.FieldAttachments,
+}
+
+// Returns the columns of the flag, the configuration file or the default
+// ones, in that order. The names are case insensitive and the spaces can
+// be replaced by dashes or underscores, or ommited like in 'lastUpdate'.
+// The ommited elements are only hidden if the flag is not used.
+func lsSelectedColumns(config *config.Core, fromFlag []string) ([]string, error) {
+	selected := fromFlag
+
+	if len(selected) == 0 {
+		selected = config.Command.Ls.Columns
+	}
+
+	if len(selected) == 0 {
+		selected = lsDefaultColumns
+	}
+
+	canonical := func(s string) string {
+		return strings.ToLower(strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.TrimSpace(s)))
+	}
 
-		// We prepare the header and rows
-		header := make(table.Row, len(c.config.Command.Ls.Columns))
-		headerColorizer := c.ColorOrNop(c.config.Colors.Two)
+	known := make(map[string]string, len(lsAllColumns))
 
-		for i, column := range c.config.Command.Ls.Columns {
-			header[i] = headerColorizer.Sprint(column)
+	for _, column := range lsAllColumns {
+		known[canonical(column)] = column
+	}
+
+	columns := make([]string, 0, len(selected))
+
+	for _, raw := range selected {
+		column, ok := known[canonical(raw)]
+		if !ok {
+			if suggestion := utils.BestMatch(lsAllColumns, strings.ToUpper(raw)); suggestion != "" {
+				return nil, fmt.Errorf("unknown column '%s', did you mean '%s'?", raw, suggestion)
+			}
+
+			return nil, fmt.Errorf("unknown column '%s', expected one of: %s", raw, strings.Join(lsAllColumns, ", "))
 		}
 
-		c.log.Trace().Msg("creating table")
+		if len(fromFlag) == 0 && lsElement(config, column).Ommit {
+			continue
+		}
 
-		// Table build and render
-		t := table.NewWriter()
-		t.SetOutputMirror(os.Stdout)
-		t.AppendHeader(header)
-		t.AppendRows(rows)
-		t.SetStyle(table.Style{
-			Box: table.StyleBoxDefault,
-			Format: table.FormatOptions{
-				Footer: text.FormatUpper,
-				Header: text.FormatTitle,
-				Row:    text.FormatDefault,
-			},
-			Options: table.OptionsNoBordersAndSeparators,
-		})
+		columns = append(columns, column)
+	}
 
-		c.log.Trace().Msg("rendering table...")
+	return columns, nil
+}
 
-		t.Render()
+func lsKeySize(config *config.Core) int {
+	if config.Command.Ls.KeySize > 2 && config.Command.Ls.KeySize < 33 {
+		return config.Command.Ls.KeySize
+	}
 
-		return nil
+	return 10
+}
+
+// The color role of every available column.
+var lsColumnRoles = map[string]string{
+	"ID":            ui.RoleID,
+	"TAG":           ui.RoleTag,
+	"SIZE":          ui.RoleSize,
+	"LAST UPDATE":   ui.RoleDate,
+	"CREATION DATE": ui.RoleCreation,
+	"TIME SPENT":    ui.RoleDuration,
+	"VERSION":       ui.RoleVersion,
+	"PICKS":         ui.RoleVersion,
+	"LAST ACCESS":   ui.RoleDate,
+	"LINES":         ui.RoleSize,
+	"WORDS":         ui.RoleSize,
+	"PREVIEW":       "",
+	"STATE":         ui.RoleTag,
+	"DUE":           ui.RoleDate,
+	"ATTACHMENTS":   ui.RoleSize,
+}
+
+// Returns the element of the configuration for the column, like
+// 'lastUpdate' for LAST UPDATE.
+func lsElement(config *config.Core, column string) config.ElementConfig {
+	return config.Element("ls", utils.ToCamelCase(column), lsColumnRoles[column])
+}
+
+func lsColumnPrinters(config *config.Core) map[string]color.PrinterFace {
+	printers := make(map[string]color.PrinterFace, len(lsColumnRoles))
+
+	for column := range lsColumnRoles {
+		printers[column] = colorOrNop(lsElement(config, column).Color, config.Command.Ls.NoColor)
 	}
+
+	return printers
 }
 
-func (c LsCmd) ColorOrNop(code string) color.PrinterFace {
-	if internal.NoColor || c.config.Command.Ls.NoColor {
-		return color.Normal
+// Returns the human-readable value of every available column.
+func lsColumnValues(n models.Note) map[string]string {
+	lastAccess := "never"
+	if !n.LastAccessedAt.IsZero() {
+		lastAccess = timeago.English.Format(n.LastAccessedAt)
+	}
+
+	var due string
+	if !n.DueAt.IsZero() {
+		due = timeago.English.Format(n.DueAt)
 	}
 
-	return ui.GetPrinter(code)
+	return map[string]string{
+		"ID":            n.Key,
+		"TAG":           n.Tag,
+		"SIZE":          n.ReadableSize(),
+		"LAST UPDATE":   timeago.English.Format(n.LastUpdate),
+		"CREATION DATE": timeago.English.Format(n.CreatedAt),
+		"TIME SPENT":    n.TimeSpent.Round(time.Second).String(),
+		"VERSION":       strconv.Itoa(n.Version),
+		"PICKS":         strconv.FormatUint(n.Picks, 10),
+		"LAST ACCESS":   lastAccess,
+		"LINES":         strconv.Itoa(n.Lines()),
+		"WORDS":         strconv.Itoa(n.Words()),
+		"PREVIEW":       n.Preview(40),
+		"STATE":         strings.Join(n.States(), ", "),
+		"DUE":           due,
+		"ATTACHMENTS":   strconv.Itoa(len(n.Attachments)),
+	}
 }
@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/luisnquin/nao/v3/internal/data"
 	"github.com/luisnquin/nao/v3/internal/models"
 	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/luisnquin/nao/v3/internal/tui"
 	"github.com/luisnquin/nao/v3/internal/ui"
 	"github.com/luisnquin/nao/v3/internal/utils"
 	"github.com/rs/zerolog"
@@ -49,7 +52,7 @@ func BuildMod(log *zerolog.Logger, config *config.Core, data *data.Buffer) ModCm
 
 	flags := c.Flags()
 	if !c.latest {
-		flags.BoolVarP(&c.latest, "latest", "l", false, "access the last modified file")
+		flags.BoolVarP(&c.latest, "latest", "l", false, "access the last accessed file, or the N-th previous one with 'mod -l N'")
 	}
 
 	flags.StringVar(&c.editor, "editor", "", "change the default code editor (ignoring configuration file)")
@@ -69,17 +72,31 @@ func (c *ModCmd) Main() cobra.PositionalArgs {
 
 			c.log.Trace().Msg("the last note accessed has been requested")
 
-			nt, err = notesRepo.LastAccessed()
+			position := 0
+
+			if len(args) == 1 {
+				position, err = strconv.Atoi(args[0])
+				if err != nil || position < 0 {
+					return fmt.Errorf("invalid position '%s' in the history, expected a number like 'mod -l 2'", args[0])
+				}
+			}
+
+			key, _, err := note.FromHistory("@~"+strconv.Itoa(position), c.data)
 			if err != nil {
 				c.log.Err(err).Msg("error encountered when trying to access the last accessed note")
 
 				return err
 			}
 
+			nt, err = notesRepo.Get(key)
+			if err != nil {
+				return err
+			}
+
 		case len(args) == 1:
 			c.log.Trace().Str("key/tag provided", args[0]).Send()
 
-			key, err := note.SearchByPrefix(args[0], c.data)
+			key, err := SearchOrPick(c.config, c.data, args[0], false)
 			if err != nil {
 				c.log.Err(err).Str("arg", args[0]).Msg("error with the argument supplied")
 
@@ -95,80 +112,125 @@ func (c *ModCmd) Main() cobra.PositionalArgs {
 				return err
 			}
 
+		case tui.IsInputInteractive():
+			c.log.Trace().Msg("no argument supplied, picking a note...")
+
+			key, err := PickNote(c.config, c.data, "")
+			if err != nil {
+				return err
+			}
+
+			nt, err = notesRepo.Get(key)
+			if err != nil {
+				return err
+			}
+
 		default:
 			c.log.Trace().Msg("no argument supplied, returning usage")
 
 			return cmd.Usage()
 		}
 
-		editorName := c.getEditorName()
-
-		var editorArgs []string
+		return c.edit(cmd.Context(), nt)
+	}
+}
 
-		unlog, err := c.logKeyInUse(nt.Key)
-		if err != nil {
-			if !c.config.ReadOnlyOnConflict {
-				return err
-			}
+// Opens the note in the editor and saves the changes, if any, along
+// with the time spent.
+func (c *ModCmd) edit(ctx context.Context, nt models.Note) error {
+	notesRepo := note.NewRepository(c.data)
 
-			editorArgs = append(editorArgs, getReadOnlyFlag(editorName))
-		} else {
-			defer func() {
-				if err := unlog(); err != nil {
-					panic(err)
-				}
-			}()
-		}
+	editorName := c.getEditorName()
 
-		c.log.Trace().Msg("creating temporary file")
+	var editorArgs []string
 
-		filePath, err := NewFileCached(c.config, nt.Key, nt.Content)
-		if err != nil {
+	unlog, err := c.logKeyInUse(nt.Key)
+	if err != nil {
+		if !c.config.ReadOnlyOnConflict {
 			return err
 		}
 
-		c.log.Trace().Str("temporary file path", filePath).Send()
-
+		editorArgs = append(editorArgs, getReadOnlyFlag(editorName))
+	} else {
 		defer func() {
-			c.log.Trace().Msg("deleting temporary file")
-
-			if err := os.Remove(filePath); err != nil {
-				c.log.Trace().Msg("unexpected error trying to delete temporary file")
-
-				ui.Error(err.Error())
+			if err := unlog(); err != nil {
+				panic(err)
 			}
 		}()
+	}
 
-		start := time.Now()
+	content, passphrase, err := unlockNote(nt)
+	if err != nil {
+		return err
+	}
 
-		c.log.Trace().Str("editor", editorName).Strs("flags", editorArgs).Msg("running editor...")
+	c.log.Trace().Msg("creating temporary file")
 
-		err = RunEditor(cmd.Context(), editorName, filePath, editorArgs...)
-		if err != nil {
-			c.log.Err(err).Msg("error running the editor")
+	filePath, err := NewFileCached(c.config, nt.Key, content)
+	if err != nil {
+		return err
+	}
 
-			return err
+	c.log.Trace().Str("temporary file path", filePath).Send()
+
+	defer func() {
+		c.log.Trace().Msg("deleting temporary file")
+
+		if err := os.Remove(filePath); err != nil {
+			c.log.Trace().Msg("unexpected error trying to delete temporary file")
+
+			ui.Error(err.Error())
 		}
+	}()
 
-		c.log.Trace().Msg("reading content of temporary file...")
+	start := time.Now()
 
-		content, err := os.ReadFile(filePath)
-		if err != nil {
-			c.log.Err(err).Msg("error reading content of temporary file")
+	c.log.Trace().Str("editor", editorName).Strs("flags", editorArgs).Msg("running editor...")
+
+	err = RunEditor(ctx, editorName, filePath, editorArgs...)
+	if err != nil {
+		c.log.Err(err).Msg("error running the editor")
+
+		return err
+	}
+
+	c.log.Trace().Msg("reading content of temporary file...")
 
+	newContent, err := os.ReadFile(filePath)
+	if err != nil {
+		c.log.Err(err).Msg("error reading content of temporary file")
+
+		return err
+	}
+
+	modifiers := []note.ModifyOption{note.WithSpentTime(time.Since(start))}
+
+	if string(newContent) == content {
+		c.log.Trace().Msg("no new content was written to the temporary file, note will not be updated")
+
+		return notesRepo.Update(nt.Key, modifiers...)
+	}
+
+	if !nt.Locked {
+		modifiers = append(modifiers, note.WithContent(string(newContent)))
+
+		if err := notesRepo.Update(nt.Key, modifiers...); err != nil {
 			return err
 		}
 
-		modifiers := []note.ModifyOption{note.WithSpentTime(time.Since(start))}
-
-		if string(content) != nt.Content {
-			modifiers = append(modifiers, note.WithContent(string(content)))
-		} else {
-			c.log.Trace().Msg("no new content was written to the temporary file, note will not be updated")
+		if !nt.Sensitive {
+			warnSecrets(nt.Tag, string(newContent))
 		}
 
-		return notesRepo.Update(nt.Key, modifiers...)
+		return nil
 	}
+
+	encrypted, err := note.Lock(string(newContent), passphrase)
+	if err != nil {
+		return err
+	}
+
+	return notesRepo.Update(nt.Key, append(modifiers, note.WithContent(encrypted))...)
 }
 
 func (c ModCmd) openKeysInUseFile() (*os.File, error) {
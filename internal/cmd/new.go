@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -24,6 +25,8 @@ type NewCmd struct {
 	editor string
 	from   string
 	tag    string
+
+	fromClipboard bool
 }
 
 func BuildNew(log *zerolog.Logger, config *config.Core, data *data.Buffer) NewCmd {
@@ -49,6 +52,7 @@ func BuildNew(log *zerolog.Logger, config *config.Core, data *data.Buffer) NewCm
 	flags.StringVar(&c.editor, "editor", "", "change the default code editor (ignoring configuration file)")
 	flags.StringVarP(&c.from, "from", "f", "", "create a copy of another file by ID or tag to edit on it")
 	flags.StringVarP(&c.tag, "tag", "t", "", "assigns a tag to the new file")
+	flags.BoolVar(&c.fromClipboard, "from-clipboard", false, "start with the content of the clipboard")
 
 	return c
 }
@@ -69,14 +73,20 @@ func (c *NewCmd) Main() cobra.PositionalArgs {
 			return fmt.Errorf("tag already exists, try 'nao mod %s'", c.tag)
 		}
 
-		key := utils.GenerateKey()
+		var seed string
 
-		path, err := NewFileCached(c.config, key, "")
-		if err != nil {
-			return err
+		if c.from != "" && c.fromClipboard {
+			return fmt.Errorf("only use one of --from and --from-clipboard")
 		}
 
-		defer os.Remove(path)
+		if c.fromClipboard {
+			content, err := pasteClipboard(c.config)
+			if err != nil {
+				return err
+			}
+
+			seed = content
+		}
 
 		if c.from != "" {
 			key, err := note.SearchByPrefix(c.from, c.data)
@@ -89,45 +99,62 @@ func (c *NewCmd) Main() cobra.PositionalArgs {
 				return err
 			}
 
-			err = ioutil.WriteFile(path, []byte(note.Content), 0o644)
+			seed, _, err = unlockNote(note)
 			if err != nil {
 				return err
 			}
 		}
 
-		start := time.Now()
+		return c.create(cmd.Context(), seed)
+	}
+}
 
-		err = RunEditor(cmd.Context(), c.getEditorName(), path)
-		if err != nil {
-			return err
-		}
+// Opens the editor with the seed and saves the new note, along with
+// the time spent, if the content is not empty.
+func (c *NewCmd) create(ctx context.Context, seed string) error {
+	key := utils.GenerateKey()
 
-		content, err := ioutil.ReadFile(path)
-		if err != nil {
-			return err
-		}
+	path, err := NewFileCached(c.config, key, seed)
+	if err != nil {
+		return err
+	}
 
-		if len(content) == 0 {
-			return fmt.Errorf("empty content, will not be saved")
-		}
+	defer os.Remove(path)
 
-		if c.tag == "" {
-			c.tag = autoname.Generate("-")
-		}
+	start := time.Now()
 
-		_, err = notesRepo.New(string(content),
-			note.WithSpentTime(time.Now().Sub(start)),
-			note.WithTag(c.tag),
-			note.WithKey(key),
-		)
-		if err != nil {
-			return err
-		}
+	err = RunEditor(ctx, c.getEditorName(), path)
+	if err != nil {
+		return err
+	}
 
-		fmt.Fprintln(os.Stdout, key[:10])
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if len(content) == 0 {
+		return fmt.Errorf("empty content, will not be saved")
+	}
 
-		return nil
+	if c.tag == "" {
+		c.tag = autoname.Generate("-")
 	}
+
+	_, err = note.NewRepository(c.data).New(string(content),
+		note.WithSpentTime(time.Now().Sub(start)),
+		note.WithTag(c.tag),
+		note.WithKey(key),
+	)
+	if err != nil {
+		return err
+	}
+
+	warnSecrets(c.tag, string(content))
+
+	fmt.Fprintln(os.Stdout, key[:10])
+
+	return nil
 }
 
 func (c *NewCmd) getEditorName() string {
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/luisnquin/nao/v3/internal/clipboard"
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+type PasteCmd struct {
+	*cobra.Command
+
+	log     *zerolog.Logger
+	config  *config.Core
+	data    *data.Buffer
+	replace bool
+}
+
+func BuildPaste(log *zerolog.Logger, config *config.Core, data *data.Buffer) PasteCmd {
+	c := PasteCmd{
+		Command: &cobra.Command{
+			Use:               "paste <id> | <tag>",
+			Short:             "Append the clipboard to a file, or create it with the clipboard if the tag doesn't exist",
+			Args:              cobra.ExactArgs(1),
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: KeyTagCompletions(data),
+		},
+		config: config,
+		data:   data,
+		log:    log,
+	}
+
+	c.RunE = c.Main()
+
+	log.Trace().Msg("the 'paste' command has been created")
+
+	c.Flags().BoolVar(&c.replace, "replace", false, "replace the content instead of appending the clipboard")
+
+	return c
+}
+
+func (c *PasteCmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		content, err := pasteClipboard(c.config)
+		if err != nil {
+			return err
+		}
+
+		notesRepo := note.NewRepository(c.data)
+
+		key, isRef, err := note.FromHistory(args[0], c.data)
+		if !isRef {
+			key, err = c.exactMatch(args[0])
+		}
+
+		if errors.Is(err, note.ErrNoteNotFound) && !isRef {
+			c.log.Trace().Str("tag", args[0]).Msg("creating note from the clipboard...")
+
+			key, err := notesRepo.New(content, note.WithTag(args[0]))
+			if err != nil {
+				return err
+			}
+
+			warnSecrets(args[0], content)
+
+			fmt.Fprintln(os.Stdout, key[:10])
+
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		nt, err := notesRepo.Peek(key)
+		if err != nil {
+			return err
+		}
+
+		if nt.Locked {
+			return fmt.Errorf("%s: %w", nt.Tag, note.ErrNoteLocked)
+		}
+
+		if !c.replace && nt.Content != "" {
+			if !strings.HasSuffix(nt.Content, "\n") {
+				nt.Content += "\n"
+			}
+
+			content = nt.Content + content
+		}
+
+		c.log.Trace().Str("key", key).Bool("replace", c.replace).Msg("pasting the clipboard into note...")
+
+		if err := notesRepo.Update(key, note.WithContent(content)); err != nil {
+			return err
+		}
+
+		if !nt.Sensitive {
+			warnSecrets(nt.Tag, content)
+		}
+
+		return nil
+	}
+}
+
+// Returns the key of the note with the exact tag or key, the prefixes aren't
+// used to not append the clipboard to an unexpected note.
+func (c *PasteCmd) exactMatch(ref string) (string, error) {
+	for _, candidate := range note.Candidates(ref, c.data) {
+		if candidate.Kind == note.ExactTag || candidate.Kind == note.ExactKey {
+			return candidate.Key, nil
+		}
+	}
+
+	return "", note.ErrNoteNotFound
+}
+
+func pasteClipboard(config *config.Core) (string, error) {
+	backend, err := clipboard.Detect(config.Clipboard.Backend)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := backend.Paste()
+	if err != nil {
+		return "", err
+	}
+
+	if strings.TrimSpace(content) == "" {
+		return "", fmt.Errorf("the clipboard is empty")
+	}
+
+	return content, nil
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/tui"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+type PickCmd struct {
+	*cobra.Command
+
+	log    *zerolog.Logger
+	config *config.Core
+	data   *data.Buffer
+	tag    bool
+}
+
+func BuildPick(log *zerolog.Logger, config *config.Core, data *data.Buffer) PickCmd {
+	c := PickCmd{
+		Command: &cobra.Command{
+			Use:               "pick [<query>]",
+			Short:             "Choose a note with a fuzzy finder and print its key",
+			Args:              cobra.MaximumNArgs(1),
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: cobra.NoFileCompletions,
+		},
+		config: config,
+		data:   data,
+		log:    log,
+	}
+
+	c.RunE = c.Main()
+
+	log.Trace().Msg("the 'pick' command has been created")
+
+	c.Flags().BoolVarP(&c.tag, "tag", "t", false, "print the tag instead of the key")
+
+	return c
+}
+
+func (c *PickCmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if !tui.IsInputInteractive() {
+			return fmt.Errorf("an interactive terminal is required")
+		}
+
+		var query string
+
+		if len(args) == 1 {
+			query = args[0]
+		}
+
+		key, err := PickNote(c.config, c.data, query)
+		if err != nil {
+			return err
+		}
+
+		c.log.Trace().Str("key", key).Msg("note picked")
+
+		if c.tag {
+			fmt.Fprintln(os.Stdout, c.data.Notes[key].Tag)
+		} else {
+			fmt.Fprintln(os.Stdout, key)
+		}
+
+		return nil
+	}
+}
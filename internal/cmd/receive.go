@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/luisnquin/nao/v3/internal/share"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+type ReceiveCmd struct {
+	*cobra.Command
+
+	log    *zerolog.Logger
+	config *config.Core
+	data   *data.Buffer
+	tag    string
+}
+
+func BuildReceive(log *zerolog.Logger, config *config.Core, data *data.Buffer) ReceiveCmd {
+	c := ReceiveCmd{
+		Command: &cobra.Command{
+			Use:           "receive <file>",
+			Short:         "Import a note shared with 'nao share', '-' reads the standard input",
+			Args:          cobra.ExactArgs(1),
+			SilenceUsage:  true,
+			SilenceErrors: true,
+		},
+		config: config,
+		data:   data,
+		log:    log,
+	}
+
+	c.RunE = c.Main()
+
+	log.Trace().Msg("the 'receive' command has been created")
+
+	c.Flags().StringVarP(&c.tag, "tag", "t", "", "import the note with another tag")
+
+	return c
+}
+
+func (c *ReceiveCmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		identity, err := share.Keys{Dir: c.config.FS.KeysDir}.Identity()
+		if err != nil {
+			return err
+		}
+
+		var src io.Reader = os.Stdin
+
+		if args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+
+			defer f.Close()
+
+			src = f
+		}
+
+		c.log.Trace().Str("file", args[0]).Msg("decrypting shared note...")
+
+		bundle, err := share.Decrypt(src, identity)
+		if err != nil {
+			return err
+		}
+
+		tag := c.tag
+		if tag == "" {
+			tag = bundle.Note.Tag
+		}
+
+		key, err := note.NewRepository(c.data).New(bundle.Note.Content,
+			note.WithTag(tag),
+			note.WithMetadataOf(bundle.Note),
+		)
+		if errors.Is(err, note.ErrTagAlreadyExists) {
+			return fmt.Errorf("%w, use --tag to receive it with another one", err)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		c.log.Trace().Str("key", key).Str("from", bundle.From).Time("shared at", bundle.SharedAt).Msg("note received")
+
+		fmt.Fprintln(os.Stdout, key[:10])
+
+		return nil
+	}
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/format"
+	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+type RecentCmd struct {
+	*cobra.Command
+
+	log      *zerolog.Logger
+	config   *config.Core
+	data     *data.Buffer
+	limit    int
+	mostUsed bool
+	quiet    bool
+	format   string
+}
+
+// The columns displayed by the recent command.
+var recentColumns = []string{"ID", "TAG", "LAST ACCESS", "PICKS", "PREVIEW"}
+
+func BuildRecent(log *zerolog.Logger, config *config.Core, data *data.Buffer) RecentCmd {
+	c := RecentCmd{
+		Command: &cobra.Command{
+			Use:               "recent",
+			Short:             "See the most recently used notes",
+			Args:              cobra.NoArgs,
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: cobra.NoFileCompletions,
+		},
+		config: config,
+		data:   data,
+		log:    log,
+	}
+
+	c.RunE = c.Main()
+
+	log.Trace().Msg("the 'recent' command has been created")
+
+	flags := c.Flags()
+	flags.IntVarP(&c.limit, "limit", "n", 10, "display at most N notes, all of them with 0")
+	flags.BoolVarP(&c.mostUsed, "most-used", "m", false, "sort by the number of accesses instead of the last one")
+	flags.BoolVarP(&c.quiet, "quiet", "q", false, "only display file ID's")
+	flags.StringVar(&c.format, "format", "", "raw output in "+strings.Join(format.Kinds(), "|")+" or a Go template like '{{.Tag}}\\t{{.Picks}}'")
+
+	return c
+}
+
+func (c *RecentCmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if c.limit < 0 {
+			return fmt.Errorf("the limit can't be negative")
+		}
+
+		query := note.Query{SortBy: note.SortAccessed, Limit: c.limit, Accessed: true}
+		if c.mostUsed {
+			query.SortBy = note.SortPicks
+		}
+
+		c.log.Trace().Interface("query", query).Msg("filtering and sorting accessed notes")
+
+		notes := query.Apply(note.NewRepository(c.data).Slice())
+
+		keySize := lsKeySize(c.config)
+
+		switch {
+		case c.quiet:
+			for _, n := range notes {
+				fmt.Fprintln(os.Stdout, n.Key[:keySize])
+			}
+
+			return nil
+
+		case c.format != "":
+			fields := make([]string, len(recentColumns))
+
+			for i, column := range recentColumns {
+				fields[i] = lsColumnFields[column]
+			}
+
+			return format.Write(os.Stdout, c.format, notes, fields)
+		}
+
+		if len(notes) == 0 {
+			c.log.Trace().Msg("no accessed notes")
+
+			return nil
+		}
+
+		// The table uses the columns of the configuration
+		recent := *c.config
+		recent.Command.Ls.Columns = recentColumns
+
+		t := lsTable(&recent, notes, lsColumnPrinters(&recent), keySize, false)
+		t.SetOutputMirror(os.Stdout)
+		t.Render()
+
+		return nil
+	}
+}
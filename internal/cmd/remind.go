@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/luisnquin/nao/v3/internal/utils"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/xeonx/timeago"
+)
+
+type RemindCmd struct {
+	*cobra.Command
+
+	log    *zerolog.Logger
+	config *config.Core
+	data   *data.Buffer
+	clear  bool
+}
+
+func BuildRemind(log *zerolog.Logger, config *config.Core, data *data.Buffer) RemindCmd {
+	c := RemindCmd{
+		Command: &cobra.Command{
+			Use:   "remind <id> | <tag> <when>",
+			Short: "Set the due date of a file, like 'tomorrow 9am', 'friday 17:30', 'in 2 hours' or 2006-01-02 15:04",
+			Example: `  nao remind todo "tomorrow 9am"
+  nao remind todo in 3 days
+  nao remind --clear todo`,
+			Args:          cobra.MinimumNArgs(1),
+			SilenceUsage:  true,
+			SilenceErrors: true,
+			ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+				if len(args) != 0 {
+					return nil, cobra.ShellCompDirectiveNoFileComp
+				}
+
+				return KeyTagCompletions(data)(cmd, args, toComplete)
+			},
+		},
+		config: config,
+		data:   data,
+		log:    log,
+	}
+
+	c.RunE = c.Main()
+
+	log.Trace().Msg("the 'remind' command has been created")
+
+	c.Flags().BoolVar(&c.clear, "clear", false, "remove the due date")
+
+	return c
+}
+
+func (c *RemindCmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if c.clear != (len(args) == 1) {
+			return cmd.Usage()
+		}
+
+		key, err := SearchOrPick(c.config, c.data, args[0], true)
+		if err != nil {
+			return err
+		}
+
+		notesRepo := note.NewRepository(c.data)
+
+		if c.clear {
+			c.log.Trace().Str("key", key).Msg("clearing due date...")
+
+			return notesRepo.Update(key, note.WithDue(time.Time{}))
+		}
+
+		due, err := utils.ParseDue(strings.Join(args[1:], " "), time.Now())
+		if err != nil {
+			return err
+		}
+
+		c.log.Trace().Str("key", key).Time("due", due).Msg("setting due date...")
+
+		if err := notesRepo.Update(key, note.WithDue(due)); err != nil {
+			return err
+		}
+
+		nt, err := notesRepo.Peek(key)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stdout, "%s is due %s (%s)\n", nt.Tag, due.Format("Mon, 02 Jan 2006 15:04"), timeago.English.Format(due))
+
+		return nil
+	}
+}
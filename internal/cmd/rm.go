@@ -7,6 +7,7 @@ import (
 	"github.com/luisnquin/nao/v3/internal/config"
 	"github.com/luisnquin/nao/v3/internal/data"
 	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/luisnquin/nao/v3/internal/tui"
 	"github.com/luisnquin/nao/v3/internal/ui"
 	"github.com/luisnquin/nao/v3/internal/utils"
 	"github.com/rs/zerolog"
@@ -27,7 +28,7 @@ func BuildRm(log *zerolog.Logger, config *config.Core, data *data.Buffer) *RmCmd
 		Command: &cobra.Command{
 			Use:               "rm [<id> | <tag>]...",
 			Short:             "Removes a file",
-			Args:              cobra.MinimumNArgs(1),
+			Args:              cobra.ArbitraryArgs,
 			SilenceUsage:      true,
 			SilenceErrors:     true,
 			ValidArgsFunction: KeyTagCompletions(data),
@@ -53,15 +54,23 @@ func (c *RmCmd) Main() cobra.PositionalArgs {
 		keys := make([]string, 0, len(args))
 		tags := make([]string, 0, len(args))
 
+		if len(args) == 0 {
+			if !tui.IsInputInteractive() {
+				return cmd.Usage()
+			}
+
+			args = []string{""}
+		}
+
 		maxSize := 0
 
 		for _, arg := range args {
-			key, err := note.SearchByPrefix(arg, c.data)
+			key, err := SearchOrPick(c.config, c.data, arg, true)
 			if err != nil {
 				return err
 			}
 
-			note, err := repo.Get(key)
+			note, err := repo.Peek(key)
 			if err != nil {
 				return err
 			}
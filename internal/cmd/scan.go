@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/goccy/go-json"
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/luisnquin/nao/v3/internal/secrets"
+	"github.com/luisnquin/nao/v3/internal/ui"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+type ScanCmd struct {
+	*cobra.Command
+
+	log    *zerolog.Logger
+	config *config.Core
+	data   *data.Buffer
+	json   bool
+}
+
+// A possible credential in a note that is not sensitive.
+type scanResult struct {
+	Tag string `json:"tag"`
+	secrets.Finding
+}
+
+func BuildScan(log *zerolog.Logger, config *config.Core, data *data.Buffer) ScanCmd {
+	c := ScanCmd{
+		Command: &cobra.Command{
+			Use:               "scan",
+			Short:             "Look for credentials in the files that aren't sensitive or locked",
+			Args:              cobra.NoArgs,
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: cobra.NoFileCompletions,
+		},
+		config: config,
+		data:   data,
+		log:    log,
+	}
+
+	c.RunE = c.Main()
+
+	log.Trace().Msg("the 'scan' command has been created")
+
+	c.Flags().BoolVar(&c.json, "json", false, "the displayed output will be in JSON format")
+
+	return c
+}
+
+func (c *ScanCmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		results := make([]scanResult, 0)
+
+		for _, n := range note.NewRepository(c.data).SliceOf(note.AllNotes) {
+			if n.Sensitive || n.Locked {
+				continue
+			}
+
+			for _, finding := range secrets.Scan(n.Content) {
+				results = append(results, scanResult{Tag: n.Tag, Finding: finding})
+			}
+		}
+
+		c.log.Trace().Int("findings", len(results)).Msg("notes scanned")
+
+		if c.json {
+			return json.NewEncoder(os.Stdout).Encode(results)
+		}
+
+		tag := colorOrNop(c.config.Element("scan", "tag", ui.RoleTag).Color, false)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+		for _, result := range results {
+			fmt.Fprintf(w, "%s\t%s\n", tag.Sprintf("%s:%d", result.Tag, result.Line), result.Kind)
+		}
+
+		return w.Flush()
+	}
+}
+
+// Warns about the credentials found in the content of a note that is not sensitive.
+func warnSecrets(tag, content string) {
+	findings := secrets.Scan(content)
+	if len(findings) == 0 {
+		return
+	}
+
+	kinds := make([]string, 0, len(findings))
+
+	for _, finding := range findings {
+		kinds = append(kinds, fmt.Sprintf("%s(line %d)", finding.Kind, finding.Line))
+	}
+
+	ui.Warning(fmt.Sprintf("%s seems to contain credentials: %s", tag, strings.Join(kinds, ", "))).
+		Suggest(fmt.Sprintf("mark it with 'nao sensitive %s' or wrap the values like [[secret:value]]", tag))
+}
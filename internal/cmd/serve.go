@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/luisnquin/nao/v3/internal"
+	"github.com/luisnquin/nao/v3/internal/api"
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/security"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+type ServeCmd struct {
+	*cobra.Command
+
+	log    *zerolog.Logger
+	config *config.Core
+	data   *data.Buffer
+	addr   string
+	socket string
+	token  string
+}
+
+func BuildServe(log *zerolog.Logger, config *config.Core, data *data.Buffer) ServeCmd {
+	c := ServeCmd{
+		Command: &cobra.Command{
+			Use:               "serve",
+			Short:             "Exposes the notes through a local HTTP/JSON API",
+			Args:              cobra.NoArgs,
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: cobra.NoFileCompletions,
+		},
+		config: config,
+		data:   data,
+		log:    log,
+	}
+
+	c.RunE = c.Main()
+
+	log.Trace().Msg("the 'serve' command has been created")
+
+	flags := c.Flags()
+	flags.StringVar(&c.addr, "addr", "127.0.0.1:4747", "the TCP address to listen on")
+	flags.StringVar(&c.socket, "socket", "", "listen on a unix socket instead of a TCP address")
+	flags.StringVar(&c.token, "token", "", "the token expected by the API (default: generated and stored in the config directory)")
+
+	return c
+}
+
+func (c *ServeCmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		token, err := c.getToken()
+		if err != nil {
+			c.log.Err(err).Msg("unable to get the API token")
+
+			return err
+		}
+
+		listener, err := c.listen()
+		if err != nil {
+			c.log.Err(err).Msg("unable to create listener")
+
+			return err
+		}
+
+		server := http.Server{
+			Handler:           api.New(c.log, c.data, token).Handler(),
+			ReadHeaderTimeout: time.Second * 5,
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		go func() {
+			<-ctx.Done()
+
+			c.log.Trace().Msg("shutting down server...")
+
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+			defer cancel()
+
+			_ = server.Shutdown(shutdownCtx)
+		}()
+
+		fmt.Fprintf(os.Stderr, "listening on %s\n", listener.Addr())
+
+		c.log.Trace().Str("addr", listener.Addr().String()).Msg("serving...")
+
+		err = server.Serve(listener)
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+
+		return err
+	}
+}
+
+func (c *ServeCmd) listen() (net.Listener, error) {
+	if c.socket == "" {
+		return net.Listen("tcp", c.addr)
+	}
+
+	// A socket left by a previous execution would make the bind fail
+	if err := os.Remove(c.socket); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	listener, err := net.Listen("unix", c.socket)
+	if err != nil {
+		return nil, err
+	}
+
+	return listener, os.Chmod(c.socket, internal.PermReadWrite)
+}
+
+// Returns the token provided by flag or the one stored in the config
+// directory, which is created the first time.
+func (c *ServeCmd) getToken() (string, error) {
+	if c.token != "" {
+		return c.token, nil
+	}
+
+	filePath := path.Join(c.config.FS.ConfigDir, "token")
+
+	content, err := os.ReadFile(filePath)
+	if err == nil && strings.TrimSpace(string(content)) != "" {
+		return strings.TrimSpace(string(content)), nil
+	}
+
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+
+	c.log.Trace().Str("file", filePath).Msg("creating a new API token...")
+
+	token := security.CreateRandomSecret()
+
+	if err := os.MkdirAll(c.config.FS.ConfigDir, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	if err := os.WriteFile(filePath, []byte(token+"\n"), internal.PermReadWrite); err != nil {
+		return "", err
+	}
+
+	fmt.Fprintf(os.Stderr, "a new token has been stored in %s\n", filePath)
+
+	return token, nil
+}
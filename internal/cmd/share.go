@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"filippo.io/age"
+	"github.com/luisnquin/nao/v3/internal"
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/luisnquin/nao/v3/internal/share"
+	"github.com/luisnquin/nao/v3/internal/utils"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+type ShareCmd struct {
+	*cobra.Command
+
+	log    *zerolog.Logger
+	config *config.Core
+	data   *data.Buffer
+	to     []string
+	output string
+	force  bool
+
+	withoutAttachments bool
+}
+
+func BuildShare(log *zerolog.Logger, config *config.Core, data *data.Buffer) ShareCmd {
+	c := ShareCmd{
+		Command: &cobra.Command{
+			Use:   "share <id> | <tag> --to <public key> | <name>...",
+			Short: "Encrypt a note for other users, they can import it with 'nao receive'",
+			Example: `  nao share deploy --to age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p
+  nao share deploy --to alice --to bob -o - | mail -s deploy team@example.com`,
+			Args:              cobra.ExactArgs(1),
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: KeyTagCompletions(data),
+		},
+		config: config,
+		data:   data,
+		log:    log,
+	}
+
+	c.RunE = c.Main()
+
+	log.Trace().Msg("the 'share' command has been created")
+
+	flags := c.Flags()
+	flags.StringArrayVar(&c.to, "to", nil, "the public key of a recipient or its name, see 'nao keys add'")
+	flags.StringVarP(&c.output, "output", "o", "", "the path of the file, by default the tag with the .age extension, '-' for the standard output")
+	flags.BoolVarP(&c.force, "force", "f", false, "overwrite the file if it already exists")
+	flags.BoolVar(&c.withoutAttachments, "without-attachments", false, "share the note even if its attachments can't be shared")
+
+	c.MarkFlagRequired("to")
+
+	c.RegisterFlagCompletionFunc("to", contactCompletions(share.Keys{Dir: config.FS.KeysDir}))
+
+	return c
+}
+
+func (c *ShareCmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		keys := share.Keys{Dir: c.config.FS.KeysDir}
+
+		recipients := make([]age.Recipient, len(c.to))
+
+		for i, ref := range c.to {
+			recipient, err := keys.Recipient(ref)
+			if err != nil {
+				return err
+			}
+
+			recipients[i] = recipient
+		}
+
+		key, err := SearchOrPick(c.config, c.data, args[0], false)
+		if err != nil {
+			return err
+		}
+
+		nt, err := note.NewRepository(c.data).Peek(key)
+		if err != nil {
+			return err
+		}
+
+		if len(nt.Attachments) > 0 && !c.withoutAttachments {
+			return fmt.Errorf("the %d attachment(s) of '%s' can't be shared, use --without-attachments to share the note without them",
+				len(nt.Attachments), nt.Tag)
+		}
+
+		// The recipients don't know the passphrase
+		if nt.Content, _, err = unlockNote(nt); err != nil {
+			return err
+		}
+
+		nt.Locked = false
+
+		var from string
+
+		identity, err := keys.Identity()
+
+		switch {
+		case err == nil:
+			from = identity.Recipient().String()
+		case !errors.Is(err, share.ErrNoIdentity):
+			return err
+		}
+
+		c.log.Trace().Str("key", key).Strs("to", c.to).Msg("encrypting note...")
+
+		var b bytes.Buffer
+
+		if err := share.Encrypt(&b, share.NewBundle(nt, from), recipients...); err != nil {
+			return err
+		}
+
+		output := c.output
+		if output == "" {
+			output = nt.Tag + ".age"
+		}
+
+		if output == "-" {
+			_, err := os.Stdout.Write(b.Bytes())
+
+			return err
+		}
+
+		if utils.FileExists(output) && !c.force {
+			return fmt.Errorf("file '%s' already exists, use --force to overwrite it", output)
+		}
+
+		if err := os.WriteFile(output, b.Bytes(), internal.PermReadWrite); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stdout, output)
+
+		return nil
+	}
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/luisnquin/nao/v3/internal/tui"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+var ErrNoArchivedNotes = errors.New("there are no archived notes")
+
+// Sets or unsets a state of the notes, like pinned or archived.
+type StateCmd struct {
+	*cobra.Command
+
+	log        *zerolog.Logger
+	config     *config.Core
+	data       *data.Buffer
+	modifier   func(bool) note.ModifyOption
+	value      bool
+	remove     bool
+	visibility note.Visibility
+}
+
+func BuildPin(log *zerolog.Logger, config *config.Core, data *data.Buffer) StateCmd {
+	c := buildState(log, config, data, &cobra.Command{
+		Use:   "pin [<id> | <tag>]...",
+		Short: "Pin files to always list them first",
+	}, note.WithPinned, true, note.Unarchived)
+
+	c.Flags().BoolVarP(&c.remove, "remove", "r", false, "unpin the files")
+
+	return c
+}
+
+func BuildFavorite(log *zerolog.Logger, config *config.Core, data *data.Buffer) StateCmd {
+	c := buildState(log, config, data, &cobra.Command{
+		Use:     "favorite [<id> | <tag>]...",
+		Aliases: []string{"fav"},
+		Short:   "Mark files as favorite, see 'nao ls --favorites'",
+	}, note.WithFavorite, true, note.Unarchived)
+
+	c.Flags().BoolVarP(&c.remove, "remove", "r", false, "unmark the files")
+
+	return c
+}
+
+func BuildSensitive(log *zerolog.Logger, config *config.Core, data *data.Buffer) StateCmd {
+	c := buildState(log, config, data, &cobra.Command{
+		Use:   "sensitive [<id> | <tag>]...",
+		Short: "Mask the whole content of files unless it's revealed, like 'nao cat --reveal'",
+	}, note.WithSensitive, true, note.Unarchived)
+
+	c.Flags().BoolVarP(&c.remove, "remove", "r", false, "unmark the files")
+
+	return c
+}
+
+func BuildArchive(log *zerolog.Logger, config *config.Core, data *data.Buffer) StateCmd {
+	return buildState(log, config, data, &cobra.Command{
+		Use:   "archive [<id> | <tag>]...",
+		Short: "Hide files from the listings, completions and searches",
+	}, note.WithArchived, true, note.Unarchived)
+}
+
+func BuildUnarchive(log *zerolog.Logger, config *config.Core, data *data.Buffer) StateCmd {
+	return buildState(log, config, data, &cobra.Command{
+		Use:   "unarchive [<id> | <tag>]...",
+		Short: "Restore archived files",
+	}, note.WithArchived, false, note.OnlyArchived)
+}
+
+// The notes of the visibility are the ones completed and picked when no argument is provided.
+func buildState(log *zerolog.Logger, config *config.Core, data *data.Buffer, command *cobra.Command,
+	modifier func(bool) note.ModifyOption, value bool, visibility note.Visibility,
+) StateCmd {
+	command.Args = cobra.ArbitraryArgs
+	command.SilenceUsage = true
+	command.SilenceErrors = true
+	command.ValidArgsFunction = keyTagCompletionsOf(data, visibility)
+
+	c := StateCmd{
+		Command:    command,
+		config:     config,
+		data:       data,
+		log:        log,
+		modifier:   modifier,
+		value:      value,
+		visibility: visibility,
+	}
+
+	c.RunE = c.Main()
+
+	log.Trace().Msgf("the '%s' command has been created", c.Name())
+
+	return c
+}
+
+func (c *StateCmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			if !tui.IsInputInteractive() {
+				return cmd.Usage()
+			}
+
+			args = []string{""}
+		}
+
+		keys := make([]string, 0, len(args))
+
+		for _, arg := range args {
+			key, err := c.search(arg)
+			if err != nil {
+				return err
+			}
+
+			keys = append(keys, key)
+		}
+
+		notesRepo := note.NewRepository(c.data)
+		value := c.value != c.remove
+
+		for _, key := range keys {
+			c.log.Trace().Str("key", key).Bool("value", value).Msgf("updating note with '%s'...", c.Name())
+
+			if err := notesRepo.Update(key, c.modifier(value)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func (c *StateCmd) search(arg string) (string, error) {
+	if arg != "" || c.visibility == note.Unarchived {
+		return SearchOrPick(c.config, c.data, arg, true)
+	}
+
+	notes := note.NewRepository(c.data).SliceOf(c.visibility)
+	if len(notes) == 0 {
+		return "", ErrNoArchivedNotes
+	}
+
+	keys := make([]string, len(notes))
+
+	for i, n := range notes {
+		keys[i] = n.Key
+	}
+
+	return PickNote(c.config, c.data, "", keys...)
+}
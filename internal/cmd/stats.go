@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/luisnquin/nao/v3/internal/stats"
+	"github.com/luisnquin/nao/v3/internal/ui"
+	"github.com/luisnquin/nao/v3/internal/utils"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+type StatsCmd struct {
+	*cobra.Command
+
+	log    *zerolog.Logger
+	config *config.Core
+	data   *data.Buffer
+	json   bool
+	weeks  int
+	top    int
+}
+
+func BuildStats(log *zerolog.Logger, config *config.Core, data *data.Buffer) StatsCmd {
+	c := StatsCmd{
+		Command: &cobra.Command{
+			Use:               "stats",
+			Short:             "See a summary of the notes and the time spent on them",
+			Args:              cobra.NoArgs,
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: cobra.NoFileCompletions,
+		},
+		config: config,
+		data:   data,
+		log:    log,
+	}
+
+	c.RunE = c.Main()
+
+	log.Trace().Msg("the 'stats' command has been created")
+
+	flags := c.Flags()
+	flags.BoolVar(&c.json, "json", false, "the displayed output will be in JSON format, sizes in bytes and times in seconds")
+	flags.IntVarP(&c.weeks, "weeks", "w", 12, "the number of weeks of the activity histogram")
+	flags.IntVar(&c.top, "top", 5, "the number of notes of the largest and most edited rankings")
+
+	return c
+}
+
+func (c *StatsCmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if c.weeks < 1 || c.top < 1 {
+			return fmt.Errorf("the number of weeks and the top must be greater than zero")
+		}
+
+		c.log.Trace().Int("weeks", c.weeks).Int("top", c.top).Msg("computing stats...")
+
+		s := stats.Compute(note.NewRepository(c.data).SliceOf(note.AllNotes), time.Now(), c.weeks, c.top)
+
+		if c.json {
+			return json.NewEncoder(os.Stdout).Encode(s)
+		}
+
+		keySize := lsKeySize(c.config)
+
+		title := colorOrNop(c.config.Element("stats", "header", ui.RoleHeader).Color, false)
+		id := colorOrNop(c.config.Element("stats", "id", ui.RoleID).Color, false)
+		tag := colorOrNop(c.config.Element("stats", "tag", ui.RoleTag).Color, false)
+
+		duration := func(seconds int64) string {
+			return (time.Duration(seconds) * time.Second).String()
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+		fmt.Fprintf(w, "%s\t%d\n", title.Sprint("Notes"), s.Notes)
+		fmt.Fprintf(w, "%s\t%s\n", title.Sprint("Total size"), utils.SizeToStorageUnits(s.Size))
+		fmt.Fprintf(w, "%s\t%s\n", title.Sprint("Time spent"), duration(s.TimeSpent))
+		fmt.Fprintf(w, "%s\t%d\n", title.Sprint("Versions"), s.Versions)
+
+		created, edited := make([]int, len(s.Weeks)), make([]int, len(s.Weeks))
+		totalCreated, totalEdited := 0, 0
+
+		for i, week := range s.Weeks {
+			created[i], edited[i] = week.Created, week.Edited
+			totalCreated += week.Created
+			totalEdited += week.Edited
+		}
+
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "%s\t%s → %s\n", title.Sprintf("Last %d weeks", len(s.Weeks)), s.Weeks[0].Start, s.Weeks[len(s.Weeks)-1].Start)
+		fmt.Fprintf(w, "%s\t%s  %d\n", title.Sprint("Created"), stats.Sparkline(created), totalCreated)
+		fmt.Fprintf(w, "%s\t%s  %d\n", title.Sprint("Edited"), stats.Sparkline(edited), totalEdited)
+
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		ranking := func(name string, entries []stats.Entry, value func(stats.Entry) string) {
+			if len(entries) == 0 {
+				return
+			}
+
+			fmt.Fprintf(w, "\n%s\n", title.Sprint(name))
+
+			for _, e := range entries {
+				fmt.Fprintf(w, "  %s\t%s\t%s\n", id.Sprint(e.Key[:keySize]), tag.Sprint(e.Tag), value(e))
+			}
+		}
+
+		ranking("Largest", s.Largest, func(e stats.Entry) string {
+			return utils.SizeToStorageUnits(e.Size)
+		})
+
+		ranking("Most edited", s.MostEdited, func(e stats.Entry) string {
+			return strconv.Itoa(e.Version) + " versions, " + duration(e.TimeSpent)
+		})
+
+		if len(s.Groups) > 0 {
+			fmt.Fprintf(w, "\n%s\n", title.Sprint("Tag groups"))
+
+			for _, g := range s.Groups {
+				notes := "notes"
+				if g.Notes == 1 {
+					notes = "note"
+				}
+
+				fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", tag.Sprint(g.Name), strings.Join([]string{strconv.Itoa(g.Notes), notes}, " "),
+					utils.SizeToStorageUnits(g.Size), duration(g.TimeSpent))
+			}
+		}
+
+		return w.Flush()
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/luisnquin/nao/v3/internal/config"
 	"github.com/luisnquin/nao/v3/internal/data"
 	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/luisnquin/nao/v3/internal/tui"
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 )
@@ -21,9 +22,9 @@ type TagCmd struct {
 func BuildTag(log *zerolog.Logger, config *config.Core, data *data.Buffer) TagCmd {
 	c := TagCmd{
 		Command: &cobra.Command{
-			Use:               "tag <old> <new>",
+			Use:               "tag [<old>] <new>",
 			Short:             "Rename the tag of any file",
-			Args:              cobra.ExactArgs(2),
+			Args:              cobra.RangeArgs(1, 2),
 			SilenceUsage:      true,
 			SilenceErrors:     true,
 			ValidArgsFunction: KeyTagCompletions(data),
@@ -45,12 +46,21 @@ func (c *TagCmd) Main() cobra.PositionalArgs {
 		notesRepo := note.NewRepository(c.data)
 		tagUtil := note.NewTagger(c.data)
 
+		// The note to rename is picked when only the new tag is provided
+		if len(args) == 1 {
+			if !tui.IsInputInteractive() {
+				return cmd.Usage()
+			}
+
+			args = []string{"", args[0]}
+		}
+
 		err := tagUtil.IsValidAsNew(args[1])
 		if err != nil {
 			return fmt.Errorf("tag %s is not valid: %w", args[1], err)
 		}
 
-		key, err := note.SearchByPrefix(args[0], c.data)
+		key, err := SearchOrPick(c.config, c.data, args[0], true)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/goccy/go-json"
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/models"
+	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/luisnquin/nao/v3/internal/secrets"
+	"github.com/luisnquin/nao/v3/internal/stats"
+	"github.com/luisnquin/nao/v3/internal/tasks"
+	"github.com/luisnquin/nao/v3/internal/ui"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+type TasksCmd struct {
+	*cobra.Command
+
+	log    *zerolog.Logger
+	config *config.Core
+	data   *data.Buffer
+	open   bool
+	done   bool
+	note   string
+	group  string
+	json   bool
+}
+
+func BuildTasks(log *zerolog.Logger, config *config.Core, data *data.Buffer) TasksCmd {
+	c := TasksCmd{
+		Command: &cobra.Command{
+			Use:               "tasks",
+			Short:             "See the checklist items(- [ ] ...) of every note",
+			Args:              cobra.NoArgs,
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: cobra.NoFileCompletions,
+		},
+		config: config,
+		data:   data,
+		log:    log,
+	}
+
+	c.RunE = c.Main()
+
+	done := &cobra.Command{
+		Use:               "done <task>...",
+		Short:             "Check or uncheck tasks by their id, like 'todo:12'",
+		Args:              cobra.MinimumNArgs(1),
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: c.idCompletions,
+		RunE:              c.Done(),
+	}
+
+	c.AddCommand(done)
+
+	log.Trace().Msg("the 'tasks' command has been created")
+
+	flags := c.Flags()
+	flags.BoolVar(&c.open, "open", false, "only the unchecked tasks")
+	flags.BoolVar(&c.done, "done", false, "only the checked tasks")
+	flags.StringVar(&c.note, "note", "", "only the tasks of a note")
+	flags.StringVar(&c.group, "group", "", "only the tasks of a tag group, like 'work' for 'work-meetings' and 'work/todo'")
+	flags.BoolVar(&c.json, "json", false, "the displayed output will be in JSON format")
+
+	c.RegisterFlagCompletionFunc("note", KeyTagCompletions(data))
+
+	return c
+}
+
+func (c *TasksCmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if c.open && c.done {
+			return fmt.Errorf("only use one of --open and --done")
+		}
+
+		notes := note.NewRepository(c.data).Slice()
+
+		if c.note != "" {
+			key, err := SearchOrPick(c.config, c.data, c.note, false)
+			if err != nil {
+				return err
+			}
+
+			nt, err := note.NewRepository(c.data).Peek(key)
+			if err != nil {
+				return err
+			}
+
+			notes = []models.Note{nt}
+		}
+
+		c.log.Trace().Int("notes", len(notes)).Msg("collecting tasks...")
+
+		result := make([]tasks.Task, 0)
+
+		for _, task := range tasks.Collect(notes) {
+			if (c.open && task.Done) || (c.done && !task.Done) {
+				continue
+			}
+
+			if c.group != "" && stats.GroupName(task.Tag) != c.group {
+				continue
+			}
+
+			result = append(result, task)
+		}
+
+		if c.json {
+			return json.NewEncoder(os.Stdout).Encode(result)
+		}
+
+		id := colorOrNop(c.config.Element("tasks", "id", ui.RoleTag).Color, false)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+		for _, task := range result {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", id.Sprint(task.ID()), taskMark(task), task.Text)
+		}
+
+		return w.Flush()
+	}
+}
+
+func (c *TasksCmd) Done() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		notesRepo := note.NewRepository(c.data)
+
+		for _, arg := range args {
+			ref, line, err := tasks.ParseID(arg)
+			if err != nil {
+				return err
+			}
+
+			key, err := SearchOrPick(c.config, c.data, ref, true)
+			if err != nil {
+				return err
+			}
+
+			nt, err := notesRepo.Peek(key)
+			if err != nil {
+				return err
+			}
+
+			if nt.Locked {
+				return fmt.Errorf("%s: %w", nt.Tag, note.ErrNoteLocked)
+			}
+
+			content, task, err := tasks.Toggle(nt.Content, line)
+			if err != nil {
+				return fmt.Errorf("%s: %w", arg, err)
+			}
+
+			c.log.Trace().Str("key", key).Int("line", line).Bool("done", task.Done).Msg("toggling task...")
+
+			if err := notesRepo.Update(key, note.WithContent(content)); err != nil {
+				return err
+			}
+
+			task.Tag = nt.Tag
+			task.Text = secrets.MaskValues(task.Text)
+
+			if nt.Sensitive {
+				task.Text = secrets.Mask
+			}
+
+			fmt.Fprintf(os.Stdout, "%s %s %s\n", taskMark(task), task.ID(), task.Text)
+		}
+
+		return nil
+	}
+}
+
+func (c *TasksCmd) idCompletions(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	all := tasks.Collect(note.NewRepository(c.data).Slice())
+
+	completions := make([]string, len(all))
+
+	for i, task := range all {
+		completions[i] = task.ID() + "\t" + taskMark(task) + " " + task.Text
+	}
+
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+func taskMark(task tasks.Task) string {
+	if task.Done {
+		return "[x]"
+	}
+
+	return "[ ]"
+}
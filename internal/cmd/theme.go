@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/models"
+	"github.com/luisnquin/nao/v3/internal/utils"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+var ErrThemeNotFound = errors.New("theme not found")
+
+type ThemeCmd struct {
+	*cobra.Command
+
+	log    *zerolog.Logger
+	config *config.Core
+}
+
+func BuildTheme(log *zerolog.Logger, config *config.Core) ThemeCmd {
+	c := ThemeCmd{
+		Command: &cobra.Command{
+			Use:               "theme",
+			Short:             "List, preview and change the color themes",
+			Args:              cobra.NoArgs,
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: cobra.NoFileCompletions,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return cmd.Usage()
+			},
+		},
+		config: config,
+		log:    log,
+	}
+
+	ls := &cobra.Command{
+		Use:               "ls",
+		Short:             "List the built-in and custom themes",
+		Args:              cobra.NoArgs,
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE:              c.Ls(),
+	}
+
+	preview := &cobra.Command{
+		Use:               "preview [<name>]...",
+		Short:             "Display the colors of the themes with a sample of 'nao ls'",
+		Args:              cobra.ArbitraryArgs,
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: c.nameCompletions(false),
+		RunE:              c.Preview(),
+	}
+
+	set := &cobra.Command{
+		Use:               "set <name>",
+		Short:             "Change the theme in the configuration file",
+		Args:              cobra.ExactArgs(1),
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: c.nameCompletions(true),
+		RunE:              c.Set(),
+	}
+
+	c.AddCommand(ls, preview, set)
+
+	log.Trace().Msg("the 'theme' command has been created")
+
+	return c
+}
+
+func (c *ThemeCmd) nameCompletions(single bool) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if single && len(args) != 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return c.config.ThemeNames(), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func (c *ThemeCmd) Ls() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		themes := c.config.Themes()
+
+		nameSize := 0
+
+		for _, theme := range themes {
+			if len(theme.Name) > nameSize {
+				nameSize = len(theme.Name)
+			}
+		}
+
+		for _, theme := range themes {
+			current := " "
+			if theme.Name == c.config.Theme {
+				current = "*"
+			}
+
+			source := theme.Source
+			if source == "" {
+				source = "built-in"
+			}
+
+			fmt.Fprintf(os.Stdout, "%s %-*s  %s  %s\n", current, nameSize, theme.Name, theme.Pretty(), source)
+		}
+
+		return nil
+	}
+}
+
+func (c *ThemeCmd) Preview() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			args = []string{c.config.Theme}
+		}
+
+		themes := make([]config.Theme, len(args))
+
+		for i, name := range args {
+			theme, err := c.find(name)
+			if err != nil {
+				return err
+			}
+
+			themes[i] = theme
+		}
+
+		for i, theme := range themes {
+			if i != 0 {
+				fmt.Fprintln(os.Stdout)
+			}
+
+			c.log.Trace().Str("theme", theme.Name).Msg("rendering preview...")
+
+			preview := *c.config
+			preview.Colors = *theme.ColorScheme
+
+			columns, err := lsSelectedColumns(&preview, nil)
+			if err != nil {
+				return err
+			}
+
+			preview.Command.Ls.Columns = columns
+
+			fmt.Fprintf(os.Stdout, "%s  %s\n\n", theme.Pretty(), theme.Name)
+
+			t := lsTable(&preview, themeSampleNotes(), lsColumnPrinters(&preview), lsKeySize(&preview), false)
+			fmt.Fprintln(os.Stdout, t.Render())
+		}
+
+		return nil
+	}
+}
+
+func (c *ThemeCmd) Set() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		theme, err := c.find(args[0])
+		if err != nil {
+			return err
+		}
+
+		c.log.Trace().Str("theme", theme.Name).Msg("updating theme in configuration file...")
+
+		return updateConfigFile(c.config, "theme", theme.Name)
+	}
+}
+
+func (c *ThemeCmd) find(name string) (config.Theme, error) {
+	theme, ok := c.config.FindTheme(name)
+	if ok {
+		return theme, nil
+	}
+
+	names := c.config.ThemeNames()
+
+	if suggestion := utils.BestMatch(names, name); suggestion != "" {
+		return config.Theme{}, fmt.Errorf("%w: '%s', did you mean '%s'?", ErrThemeNotFound, name, suggestion)
+	}
+
+	return config.Theme{}, fmt.Errorf("%w: '%s', expected one of: %s", ErrThemeNotFound, name, strings.Join(names, ", "))
+}
+
+// Fake notes to show how 'nao ls' looks like.
+func themeSampleNotes() []models.Note {
+	now := time.Now()
+
+	return []models.Note{
+		{
+			Key: "8c2f5a1e9b7d40c3a6e1f0d2b4c68e97", Tag: "groceries", Content: "- milk\n- green tea",
+			CreatedAt: now.Add(-72 * time.Hour), LastUpdate: now.Add(-2 * time.Hour),
+			TimeSpent: 4 * time.Minute, Version: 3,
+		},
+		{
+			Key: "1f9e3b7c2a5d48e0b6c4d1a8f3e2970b", Tag: "todo", Content: "- [ ] write the docs",
+			CreatedAt: now.Add(-240 * time.Hour), LastUpdate: now.Add(-26 * time.Hour),
+			TimeSpent: 37 * time.Minute, Version: 12,
+		},
+		{
+			Key: "e4a7c9d1b3f2465a8e0c7b9d2f1a3c5e", Tag: "ideas", Content: "A tool to take notes",
+			CreatedAt: now.Add(-720 * time.Hour), LastUpdate: now.Add(-168 * time.Hour),
+			TimeSpent: 2 * time.Hour, Version: 27,
+		},
+	}
+}
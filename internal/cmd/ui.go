@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gookit/color"
+	"github.com/luisnquin/nao/v3/internal/clipboard"
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/luisnquin/nao/v3/internal/secrets"
+	"github.com/luisnquin/nao/v3/internal/tui"
+	"github.com/luisnquin/nao/v3/internal/ui"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+type UICmd struct {
+	*cobra.Command
+
+	log    *zerolog.Logger
+	config *config.Core
+	data   *data.Buffer
+	mod    ModCmd
+}
+
+func BuildUI(log *zerolog.Logger, config *config.Core, data *data.Buffer) UICmd {
+	c := UICmd{
+		Command: &cobra.Command{
+			Use:               "ui",
+			Short:             "Browse the notes in an interactive terminal interface",
+			Args:              cobra.NoArgs,
+			SilenceUsage:      true,
+			SilenceErrors:     true,
+			ValidArgsFunction: cobra.NoFileCompletions,
+		},
+		mod:    BuildMod(log, config, data),
+		config: config,
+		data:   data,
+		log:    log,
+	}
+
+	c.RunE = c.Main()
+
+	log.Trace().Msg("the 'ui' command has been created")
+
+	c.Flags().StringVar(&c.mod.editor, "editor", "", "change the default code editor (ignoring configuration file)")
+
+	return c
+}
+
+func (c *UICmd) Main() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if !tui.IsInteractive() {
+			return fmt.Errorf("an interactive terminal is required")
+		}
+
+		notesRepo := note.NewRepository(c.data)
+
+		columns, err := lsSelectedColumns(c.config, nil)
+		if err != nil {
+			return err
+		}
+
+		printers := lsColumnPrinters(c.config)
+		columnPrinters := make([]color.PrinterFace, len(columns))
+
+		for i, column := range columns {
+			if printer, ok := printers[column]; ok {
+				columnPrinters[i] = printer
+			} else {
+				columnPrinters[i] = color.Normal
+			}
+		}
+
+		keySize := lsKeySize(c.config)
+
+		browser := tui.Browser{
+			Header: columns,
+			Colors: tui.BrowserColors{
+				Header:  colorOrNop(c.config.Element("ui", "header", ui.RoleHeader).Color, c.config.Command.Ls.NoColor),
+				Accent:  colorOrNop(c.config.Element("ui", "accent", ui.RoleAccent).Color, c.config.Command.Ls.NoColor),
+				Columns: columnPrinters,
+			},
+			Load: func() ([]tui.Item, error) {
+				notes := notesRepo.Slice()
+
+				sort.SliceStable(notes, func(i, j int) bool {
+					return notes[i].LastUpdate.After(notes[j].LastUpdate)
+				})
+
+				items := make([]tui.Item, len(notes))
+
+				for i, n := range notes {
+					key := n.Key
+					n.Key = n.Key[:keySize]
+
+					values := lsColumnValues(n)
+					cells := make([]string, len(columns))
+
+					for j, column := range columns {
+						cells[j] = values[column]
+					}
+
+					items[i] = tui.Item{Key: key, Tag: n.Tag, Cells: cells, Content: n.MaskedContent()}
+				}
+
+				return items, nil
+			},
+			Edit: func(key string) error {
+				nt, err := notesRepo.Get(key)
+				if err != nil {
+					return err
+				}
+
+				return c.mod.edit(cmd.Context(), nt)
+			},
+			Rename: func(key, tag string) error {
+				if err := note.NewTagger(c.data).IsValidAsNew(tag); err != nil {
+					return fmt.Errorf("tag %s is not valid: %w", tag, err)
+				}
+
+				return notesRepo.Update(key, note.WithTag(tag))
+			},
+			Delete: notesRepo.Delete,
+			Copy: func(key string) error {
+				nt, err := notesRepo.Get(key)
+				if err != nil {
+					return err
+				}
+
+				// The passphrase can't be typed in the browser
+				if nt.Locked {
+					return note.ErrNoteLocked
+				}
+
+				backend, err := clipboard.Detect(c.config.Clipboard.Backend)
+				if err != nil {
+					return err
+				}
+
+				return backend.Copy(secrets.Reveal(nt.Content))
+			},
+		}
+
+		c.log.Trace().Msg("running browser...")
+
+		return browser.Run()
+	}
+}
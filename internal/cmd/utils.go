@@ -2,16 +2,22 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/gookit/color"
 	"github.com/luisnquin/nao/v3/internal"
 	"github.com/luisnquin/nao/v3/internal/config"
 	"github.com/luisnquin/nao/v3/internal/data"
 	"github.com/luisnquin/nao/v3/internal/note"
+	"github.com/luisnquin/nao/v3/internal/tui"
+	"github.com/luisnquin/nao/v3/internal/ui"
+	"github.com/luisnquin/nao/v3/internal/utils"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
@@ -56,31 +62,114 @@ func NewFileCached(config *config.Core, key, content string) (string, error) {
 	return f.Name(), f.Close()
 }
 
+// Completes the keys and tags of the notes that aren't archived and the history references.
 func KeyTagCompletions(data *data.Buffer) func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return keyTagCompletionsOf(data, note.Unarchived)
+}
+
+func keyTagCompletionsOf(data *data.Buffer, visibility note.Visibility) func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return note.SearchKeyTagsByPrefix(toComplete, data), cobra.ShellCompDirectiveNoFileComp
+		if strings.HasPrefix(toComplete, "@") {
+			var refs []string
+
+			for _, ref := range note.HistoryReferences(data) {
+				if strings.HasPrefix(ref, toComplete) {
+					refs = append(refs, ref)
+				}
+			}
+
+			return append(refs, note.SearchKeyTagsOf(toComplete, data, visibility)...), cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return note.SearchKeyTagsOf(toComplete, data, visibility), cobra.ShellCompDirectiveNoFileComp
 	}
 }
 
-func NavigateMapAndSet(m map[string]any, path string, value any) error {
-	parts := strings.Split(path, ".")
+// Returns the key of the note referenced by the argument. If the argument is empty or
+// matches more than one note and the input is interactive, the user picks the note.
+//
+// Otherwise, ambiguous arguments are an error in strict mode, meant for destructive
+// operations, or resolved to the best ranked candidate.
+func SearchOrPick(config *config.Core, data *data.Buffer, arg string, strict bool) (string, error) {
+	if arg == "" {
+		if !tui.IsInputInteractive() {
+			return "", note.ErrNoteNotFound
+		}
 
-	for i, part := range parts {
-		i++
+		return PickNote(config, data, "")
+	}
+
+	key, err := note.Resolve(arg, data)
 
-		if len(parts) == i {
-			m[part] = value
+	var ambiguityErr *note.AmbiguityError
 
-			return nil
+	if errors.As(err, &ambiguityErr) {
+		if tui.IsInputInteractive() {
+			return PickNote(config, data, "", ambiguityErr.Keys()...)
 		}
 
-		v, ok := m[part].(map[string]any)
-		if ok {
-			m = v
+		if !strict {
+			return ambiguityErr.Candidates[0].Key, nil
 		}
 	}
 
-	return fmt.Errorf("key doesn't contain a section: %s", parts[len(parts)-1])
+	return key, err
+}
+
+// Lets the user choose a note with the fuzzy finder, every note that isn't
+// archived is a candidate if no keys are provided.
+func PickNote(config *config.Core, data *data.Buffer, query string, keys ...string) (string, error) {
+	visibility := note.Unarchived
+	if len(keys) > 0 {
+		visibility = note.AllNotes
+	}
+
+	notes := note.NewRepository(data).SliceOf(visibility)
+
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].LastUpdate.After(notes[j].LastUpdate)
+	})
+
+	candidates := make([]tui.Candidate, 0, len(notes))
+
+	for _, n := range notes {
+		if len(keys) > 0 && !utils.Contains(keys, n.Key) {
+			continue
+		}
+
+		candidates = append(candidates, tui.Candidate{
+			Key:    note.ShortKey(n.Key),
+			Tag:    n.Tag,
+			Detail: n.Preview(60),
+			Value:  n.Key,
+		})
+	}
+
+	picker := tui.Picker{
+		Query:      query,
+		Candidates: candidates,
+		Colors: tui.PickerColors{
+			Accent: colorOrNop(config.Element("", "accent", ui.RoleAccent).Color, false),
+			Match:  colorOrNop(config.Element("", "match", ui.RoleMatch).Color, false),
+		},
+	}
+
+	candidate, err := picker.Pick()
+	if err != nil {
+		return "", err
+	}
+
+	return candidate.Value, nil
+}
+
+// Returns the printer of the color or a plain one if the colors are
+// disabled, globally or by the command.
+func colorOrNop(code string, noColor bool) color.PrinterFace {
+	if internal.NoColor || noColor {
+		return color.Normal
+	}
+
+	return ui.GetPrinter(code)
 }
 
 func NavigateMapAndGet(m map[string]any, path string) (string, error) {
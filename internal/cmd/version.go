@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"net/http"
 	"os"
 	"path"
@@ -135,7 +136,7 @@ func (c VersionCmd) Main() cobra.PositionalArgs {
 			b.WriteString("bleak...")
 		}
 
-		color := c.config.Colors.One
+		color := c.config.Element("version", "version", ui.RoleAccent).Color
 
 		if c.config.Command.Version.Color != "" {
 			color = c.config.Command.Version.Color
@@ -143,6 +144,12 @@ func (c VersionCmd) Main() cobra.PositionalArgs {
 
 		c.log.Trace().Msg("rendering current version...")
 
+		if internal.NoColor || c.config.Command.Version.NoColor {
+			fmt.Fprintln(os.Stdout, b.String())
+
+			return nil
+		}
+
 		ui.GetPrinter(color).Println(b.String())
 
 		return nil
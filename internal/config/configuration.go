@@ -1,6 +1,7 @@
 package config
 
 import (
+	_ "embed"
 	"errors"
 	"fmt"
 	"io"
@@ -8,6 +9,7 @@ import (
 	"os"
 	"path"
 	"runtime"
+	"time"
 
 	"github.com/ProtonMail/go-appdir"
 	"github.com/luisnquin/nao/v3/internal"
@@ -17,16 +19,29 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+var ErrInvalidFile = errors.New("invalid configuration file")
+
+// The commented configuration file used when resetting.
+//
+//go:embed default.config.yml
+var DefaultFile []byte
+
 type Core struct {
-	Encrypt            bool           `json:"-" yaml:"-"`
-	Editor             EditorConfig   `json:"editor" yaml:"editor"`
-	Theme              string         `json:"theme" yaml:"theme"`
-	ReadOnlyOnConflict bool           `json:"readOnlyOnConflict" yaml:"readOnlyOnConflict"`
-	Command            CommandOptions `json:"-" yaml:"-"`
-	FS                 FSConfig       `json:"-" yaml:"-"`
-	Colors             ui.ColorScheme `json:"-" yaml:"-"` // ???
-
-	log *zerolog.Logger
+	Encrypt            bool             `json:"-" yaml:"-"`
+	Editor             EditorConfig     `json:"editor" yaml:"editor"`
+	Theme              string           `json:"theme" yaml:"theme"`
+	ReadOnlyOnConflict bool             `json:"readOnlyOnConflict" yaml:"readOnlyOnConflict"`
+	Clipboard          ClipboardConfig  `json:"-" yaml:"clipboard"`
+	Keyring            KeyringConfig    `json:"-" yaml:"keyring"`
+	Command            CommandOptions   `json:"-" yaml:",inline"`
+	CustomThemes       []ui.ColorScheme `json:"-" yaml:"themes,omitempty"`
+	Elements           Elements         `json:"-" yaml:"elements,omitempty"`
+	FS                 FSConfig         `json:"-" yaml:"-"`
+	Colors             ui.ColorScheme   `json:"-" yaml:"-"` // ???
+
+	log       *zerolog.Logger
+	origins   map[string]Origin
+	dirThemes []Theme
 }
 
 type FSConfig struct {
@@ -34,6 +49,10 @@ type FSConfig struct {
 	DataNormalFile    string
 	ConfigFile        string
 	ConfigDir         string
+	ThemesDir         string
+	BlobsDir          string
+	KeysDir           string
+	KeystoreFile      string
 	CacheDir          string
 	DataDir           string
 }
@@ -46,6 +65,22 @@ func (fs *FSConfig) DataFile(forEncrypted bool) string {
 	return fs.DataNormalFile
 }
 
+type ClipboardConfig struct {
+	// One of clipboard.Backends, the first available one if empty or auto.
+	Backend string `yaml:"backend"`
+	// Clears the clipboard after copying a note, never if zero.
+	ClearAfter time.Duration `yaml:"clearAfter"`
+}
+
+type KeyringConfig struct {
+	// One of security.Stores, where the secret that encrypts the data is stored.
+	Backend string `yaml:"backend"`
+	// The entry of pass with the secret.
+	PassEntry string `yaml:"passEntry"`
+	// The environment variable with the secret.
+	Variable string `yaml:"variable"`
+}
+
 type EditorConfig struct {
 	Name      string   `json:"name" yaml:"name"`
 	ExtraArgs []string `json:"extraArgs" yaml:"extraArgs"`
@@ -53,26 +88,60 @@ type EditorConfig struct {
 
 type (
 	CommandOptions struct {
-		Version VersionConfig `yaml:"version"`
-		Ls      LsConfig      `yaml:"ls"`
+		Version     VersionConfig  `yaml:"version"`
+		Ls          LsConfig       `yaml:"ls"`
+		Cat         CatConfig      `yaml:"cat"`
+		Journal     JournalConfig  `yaml:"journal"`
+		Attachments ElementsConfig `yaml:"attachments,omitempty"`
+		Keys        ElementsConfig `yaml:"keys,omitempty"`
+		Scan        ElementsConfig `yaml:"scan,omitempty"`
+		Stats       ElementsConfig `yaml:"stats,omitempty"`
+		Tasks       ElementsConfig `yaml:"tasks,omitempty"`
+		UI          ElementsConfig `yaml:"ui,omitempty"`
+	}
+
+	// The options of the commands that only have elements.
+	ElementsConfig struct {
+		Elements Elements `yaml:"elements,omitempty"`
+	}
+
+	JournalConfig struct {
+		// The tag of the entries as a Go time layout, like journal-2006-01-02.
+		Pattern string `yaml:"pattern"`
+		// The key or tag of the note that seeds the new entries.
+		Template string `yaml:"template,omitempty"`
+	}
+
+	CatConfig struct {
+		// Renders the notes as Markdown when the output is a terminal.
+		Render bool `yaml:"render"`
+		// Long rendered notes aren't sent to $PAGER.
+		NoPager  bool     `yaml:"noPager"`
+		Elements Elements `yaml:"elements,omitempty"`
 	}
 
 	VersionConfig struct {
-		NoColor bool   `yaml:"noColor,omitempty"`
-		Color   string `yaml:"color,omitempty"`
+		NoColor  bool     `yaml:"noColor,omitempty"`
+		Color    string   `yaml:"color,omitempty"`
+		Elements Elements `yaml:"elements,omitempty"`
 	}
 
 	LsConfig struct {
-		KeySize int  `yaml:"keyLength,omitempty"`
-		NoColor bool `yaml:"NoColor,omitempty"`
-		Columns []string
+		KeySize  int      `yaml:"keyLength,omitempty"`
+		NoColor  bool     `yaml:"noColor,omitempty"`
+		Columns  []string `yaml:"columns,omitempty"`
+		Elements Elements `yaml:"elements,omitempty"`
 	}
 
+	// Overrides an element of the interface, like a column of 'nao ls'.
 	ElementConfig struct {
 		Alias string `yaml:"alias,omitempty"`
 		Color string `yaml:"color,omitempty"`
-		Ommit bool   `yaml:"ommit"`
+		Ommit bool   `yaml:"ommit,omitempty"`
 	}
+
+	// The elements by name.
+	Elements map[string]ElementConfig
 )
 
 func New(logger *zerolog.Logger) (*Core, error) {
@@ -104,9 +173,12 @@ func New(logger *zerolog.Logger) (*Core, error) {
 			return New(logger)
 		}
 
-		logger.Err(err).Msg("the error cannot be dealt with, sending a panic message")
+		logger.Err(err).Msg("the error cannot be dealt with, falling back to the default configuration")
+
+		config.fillOrFix()
+		config.UpdateTheme(config.Theme)
 
-		panic(err)
+		return &config, err
 	}
 
 	logger.Trace().Msgf("loading '%s' theme or default", config.Theme)
@@ -123,22 +195,44 @@ func (c *Core) Load() error {
 	c.FS = FSConfig{
 		ConfigFile: path.Join(configDir, "config.yml"),
 		ConfigDir:  configDir,
+		ThemesDir:  path.Join(configDir, "themes"),
+		KeysDir:    path.Join(configDir, "keys"),
 		CacheDir:   cacheDir,
 		DataDir:    dataDir,
+		BlobsDir:   path.Join(dataDir, "blobs"),
 	}
 
 	c.FS.DataEncryptedFile = path.Join(dataDir, "data.txt")
 	c.FS.DataNormalFile = path.Join(dataDir, "data.json")
+	c.FS.KeystoreFile = path.Join(dataDir, "keystore") // Only used by the file secret store
+
+	c.origins = make(map[string]Origin)
+
+	c.log.Trace().Msg("applying default configuration...")
+
+	if err := c.applyLayer(LayerDefault, "", DefaultFile); err != nil {
+		return err
+	}
 
-	files := []string{c.FS.ConfigFile}
+	type layerFile struct{ layer, file string }
+
+	var files []layerFile
 
 	if utils.Contains([]string{"linux", "darwin"}, runtime.GOOS) {
-		files = append(files, "/etc/nao/config.yml")
+		files = append(files, layerFile{LayerSystem, SystemFile})
 	}
 
-	c.log.Trace().Strs("target configuration files", files).Msg("reading...")
+	files = append(files, layerFile{LayerUser, c.FS.ConfigFile})
+
+	if file, ok := findProjectFile(); ok {
+		files = append(files, layerFile{LayerProject, file})
+	}
+
+	c.log.Trace().Interface("target configuration files", files).Msg("reading...")
+
+	for _, target := range files {
+		file := target.file
 
-	for _, file := range files {
 		info, err := os.Stat(file)
 		if err != nil {
 			c.log.Err(err).Str("file", file).Msg("failed attempt to stat, skipping...")
@@ -149,11 +243,10 @@ func (c *Core) Load() error {
 		if info.IsDir() {
 			c.log.Trace().Msg("why is the config file a directory? exiting...")
 
-			ui.Fatalf("config file %s is a directory", file).Suggest("delete the directory")
-			os.Exit(1)
+			return fmt.Errorf("%w: %s is a directory, delete it", ErrInvalidFile, file)
 		}
 
-		c.log.Trace().Str("file", file).Msg("loading configuration file...")
+		c.log.Trace().Str("file", file).Str("layer", target.layer).Msg("loading configuration file...")
 
 		data, err := os.ReadFile(file)
 		if err != nil && !errors.Is(err, io.EOF) {
@@ -164,17 +257,38 @@ func (c *Core) Load() error {
 
 		c.log.Trace().Msg("encoding configuration file data...")
 
-		err = yaml.Unmarshal(data, c)
+		err = c.applyLayer(target.layer, file, data)
 		if err != nil {
 			c.log.Trace().Str("file", file).Msg("config file is not a valid yaml")
 
-			ui.Fatalf("config file %s is not a valid yaml", file).Suggest("fix or delete the file")
-			os.Exit(1)
+			return fmt.Errorf("%w: %s is not a valid yaml, fix it with 'nao config edit' or reset it with 'nao config reset': %s",
+				ErrInvalidFile, file, err.Error())
 		}
 
 		c.log.Trace().Msg("file loaded into memory successfully")
 	}
 
+	c.log.Trace().Msg("applying environment variables...")
+
+	if err := c.applyEnv(); err != nil {
+		return err
+	}
+
+	// The editor is executed, whatever the layer that sets it
+	if !utils.Contains([]string{internal.Nano, internal.Neovim, internal.Vim}, c.Editor.Name) {
+		return fmt.Errorf("%w: unsupported editor '%s' from %s, expected one of: %s, %s, %s",
+			ErrInvalidFile, c.Editor.Name, c.Origin("editor.name"), internal.Nano, internal.Neovim, internal.Vim)
+	}
+
+	c.log.Trace().Str("dir", c.FS.ThemesDir).Msg("loading themes directory...")
+
+	themes, err := LoadThemesDir(c.FS.ThemesDir)
+	if err != nil {
+		c.log.Err(err).Msg("some themes couldn't be loaded, skipping...")
+	}
+
+	c.dirThemes = themes
+
 	c.log.Trace().Msg("the data encryption feature is being forced")
 	c.Encrypt = true
 
@@ -197,7 +311,7 @@ func (c *Core) fillOrFix() {
 		c.Editor.Name = internal.Nano
 	}
 
-	if !utils.Contains(ui.GetThemeNames(), c.Theme) {
+	if !utils.Contains(c.ThemeNames(), c.Theme) {
 		c.log.Debug().Str("target", c.Theme).Msg("provided unrecognized theme in configuration file")
 
 		c.Theme = ui.Default
@@ -211,22 +325,14 @@ func (c *Core) adoptTheme(theme *ui.ColorScheme) {
 }
 
 func (c *Core) UpdateTheme(name string) {
-	switch name { // The configuration should not be updated for this
-	case ui.Nord:
-		c.adoptTheme(ui.GetNordTheme())
-	case ui.Nop:
-		c.adoptTheme(ui.NoTheme)
-	case ui.Party:
-		c.adoptTheme(ui.GetPartyTheme())
-	case ui.BeachDay:
-		c.adoptTheme(ui.GetBeachDayTheme())
-	case ui.RosePine:
-		c.adoptTheme(ui.GetRosePineTheme())
-	case ui.RosePineDawn:
-		c.adoptTheme(ui.GetRosePineDawnTheme())
-	case ui.RosePineMoon:
-		c.adoptTheme(ui.GetRosePineMoonTheme())
-	default:
+	// The configuration should not be updated for this
+	if theme, ok := c.FindTheme(name); ok {
+		c.adoptTheme(theme.ColorScheme)
+	} else {
 		c.adoptTheme(ui.GetDefaultTheme())
 	}
+
+	for _, role := range []string{ui.RoleError, ui.RoleSuggestion, ui.RolePrompt} {
+		ui.SetMessageColor(role, c.Element("", role, role).Color)
+	}
 }
@@ -0,0 +1,42 @@
+package config
+
+// Returns the configuration of an element displayed by the command. The
+// options of the command take precedence over the global ones and the
+// color of the role is used when the element doesn't have one. Only the
+// global options are used if the command is empty.
+func (c *Core) Element(command, name, role string) ElementConfig {
+	element := c.Elements[name]
+
+	if override, ok := c.Command.elements()[command][name]; ok {
+		if override.Alias != "" {
+			element.Alias = override.Alias
+		}
+
+		if override.Color != "" {
+			element.Color = override.Color
+		}
+
+		element.Ommit = element.Ommit || override.Ommit
+	}
+
+	if element.Color == "" && role != "" {
+		element.Color = c.Colors.Role(role)
+	}
+
+	return element
+}
+
+// Returns the elements of every command by its name.
+func (o *CommandOptions) elements() map[string]Elements {
+	return map[string]Elements{
+		"attachments": o.Attachments.Elements,
+		"cat":         o.Cat.Elements,
+		"keys":        o.Keys.Elements,
+		"ls":          o.Ls.Elements,
+		"scan":        o.Scan.Elements,
+		"stats":       o.Stats.Elements,
+		"tasks":       o.Tasks.Elements,
+		"ui":          o.UI.Elements,
+		"version":     o.Version.Elements,
+	}
+}
@@ -0,0 +1,49 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/ui"
+)
+
+func TestElement(t *testing.T) {
+	var c config.Core
+
+	c.Colors = ui.ColorScheme{Three: "#5e81ac", Four: "#88c0d0", Roles: map[string]string{ui.RoleTag: "212"}}
+	c.Elements = config.Elements{
+		"id":  {Alias: "KEY", Color: "red"},
+		"tag": {Ommit: true},
+	}
+	c.Command.Ls.Elements = config.Elements{"id": {Color: "blue"}}
+	c.Command.Cat.Elements = config.Elements{"heading": {Color: "green"}}
+	c.Command.Tasks.Elements = config.Elements{"id": {Alias: "TASK"}}
+
+	if e := c.Element("ls", "id", ui.RoleID); e.Alias != "KEY" || e.Color != "blue" {
+		t.Errorf("expected the alias of the global element and the color of the command, got %+v", e)
+	}
+
+	if e := c.Element("version", "id", ui.RoleID); e.Color != "red" {
+		t.Errorf("expected the color of the global element, got %+v", e)
+	}
+
+	if e := c.Element("cat", "heading", ui.RoleAccent); e.Color != "green" {
+		t.Errorf("expected the color of the cat element, got %+v", e)
+	}
+
+	if e := c.Element("tasks", "id", ui.RoleTag); e.Alias != "TASK" || e.Color != "red" {
+		t.Errorf("expected the alias of the tasks element and the global color, got %+v", e)
+	}
+
+	if e := c.Element("", "heading", ui.RoleAccent); e.Color != "" {
+		t.Errorf("expected only the global elements without a command, got %+v", e)
+	}
+
+	if e := c.Element("ls", "tag", ui.RoleTag); !e.Ommit || e.Color != "212" {
+		t.Errorf("expected the color of the role overridden by the theme, got %+v", e)
+	}
+
+	if e := c.Element("ls", "size", ui.RoleSize); e.Color != "" {
+		t.Errorf("expected the empty slot of the role, got %+v", e)
+	}
+}
@@ -0,0 +1,366 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/luisnquin/nao/v3/internal"
+	"github.com/luisnquin/nao/v3/internal/clipboard"
+	"github.com/luisnquin/nao/v3/internal/security"
+	"github.com/luisnquin/nao/v3/internal/ui"
+	"github.com/luisnquin/nao/v3/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrUnknownKey = errors.New("unknown configuration key")
+
+// The keys that were renamed, by their old name. They're still accepted
+// and 'nao config set' rewrites them.
+var legacyKeys = map[string]string{
+	"ls.NoColor": "ls.noColor",
+}
+
+// Returns the dotted paths of all the options available in the
+// configuration file, sorted alphabetically.
+func Keys() []string {
+	fields := make(map[string]reflect.Type)
+	collectKeys(reflect.TypeOf(Core{}), "", fields)
+
+	keys := make([]string, 0, len(fields))
+
+	for key := range fields {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func collectKeys(t reflect.Type, prefix string, fields map[string]reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, inline := yamlName(field)
+		if name == "-" {
+			continue
+		}
+
+		if inline {
+			collectKeys(field.Type, prefix, fields)
+
+			continue
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			collectKeys(field.Type, prefix+name+".", fields)
+
+			continue
+		}
+
+		fields[prefix+name] = field.Type
+	}
+}
+
+// Returns the name of the field in the YAML file and if it's inlined.
+func yamlName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("yaml")
+	parts := strings.Split(tag, ",")
+
+	inline := utils.Contains(parts[1:], "inline")
+
+	if parts[0] == "" {
+		return strings.ToLower(field.Name), inline
+	}
+
+	return parts[0], inline
+}
+
+// Returns the type expected by the key or an error if the key doesn't exist.
+func KeyType(key string) (reflect.Type, error) {
+	fields := make(map[string]reflect.Type)
+	collectKeys(reflect.TypeOf(Core{}), "", fields)
+
+	t, ok := fields[key]
+	if ok {
+		return t, nil
+	}
+
+	// Sections like 'editor' are valid keys for reading
+	for k := range fields {
+		if strings.HasPrefix(k, key+".") {
+			return nil, nil
+		}
+	}
+
+	if suggestion := utils.BestMatch(Keys(), key); suggestion != "" {
+		return nil, fmt.Errorf("%w '%s', did you mean '%s'?", ErrUnknownKey, key, suggestion)
+	}
+
+	return nil, fmt.Errorf("%w '%s'", ErrUnknownKey, key)
+}
+
+// Parses the raw value as YAML and checks that it's compatible with
+// the type of the key.
+func ParseValue(key, raw string) (any, error) {
+	t, err := KeyType(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if t == nil {
+		return nil, fmt.Errorf("'%s' is a section, set one of its keys instead", key)
+	}
+
+	target := reflect.New(t)
+
+	if err := yaml.Unmarshal([]byte(raw), target.Interface()); err != nil {
+		return nil, fmt.Errorf("invalid value for '%s', expected %s: %s", key, describeType(t), raw)
+	}
+
+	var value any
+
+	if err := yaml.Unmarshal([]byte(raw), &value); err != nil {
+		return nil, err
+	}
+
+	// An empty value for a string key would be decoded as null
+	if value == nil && t.Kind() == reflect.String {
+		value = ""
+	}
+
+	return value, nil
+}
+
+func describeType(t reflect.Type) string {
+	if t == reflect.TypeOf(time.Duration(0)) {
+		return "a duration like 30s"
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return "an integer"
+	case reflect.Slice:
+		return "a list like [a, b]"
+	case reflect.Map:
+		return "a mapping"
+	}
+
+	return "a " + t.Kind().String()
+}
+
+// Checks that the content is a valid configuration file, without
+// unknown keys and with supported values. The themes of the themes
+// directory of the file system configuration are valid theme names.
+func Validate(fs FSConfig, content []byte) error {
+	content, err := withCurrentKeys(content)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidFile, err.Error())
+	}
+
+	var c Core
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	// An empty document isn't an error
+	if err := decoder.Decode(&c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: %s", ErrInvalidFile, err.Error())
+	}
+
+	if c.Editor.Name != "" && !utils.Contains([]string{internal.Nano, internal.Neovim, internal.Vim}, c.Editor.Name) {
+		return fmt.Errorf("%w: unsupported editor '%s', expected one of: %s, %s, %s",
+			ErrInvalidFile, c.Editor.Name, internal.Nano, internal.Neovim, internal.Vim)
+	}
+
+	commands := c.Command.elements()
+	commands[""] = c.Elements
+
+	for _, elements := range commands {
+		for name, element := range elements {
+			if element.Color != "" && !ui.IsColor(element.Color) {
+				return fmt.Errorf("%w: unsupported color '%s' for the element '%s'", ErrInvalidFile, element.Color, name)
+			}
+		}
+	}
+
+	if c.Clipboard.Backend != "" && !utils.Contains(clipboard.Backends(), c.Clipboard.Backend) {
+		return fmt.Errorf("%w: unsupported clipboard backend '%s', expected one of: %s",
+			ErrInvalidFile, c.Clipboard.Backend, strings.Join(clipboard.Backends(), ", "))
+	}
+
+	if c.Keyring.Backend != "" && !utils.Contains(security.Stores(), c.Keyring.Backend) {
+		return fmt.Errorf("%w: unsupported keyring backend '%s', expected one of: %s",
+			ErrInvalidFile, c.Keyring.Backend, strings.Join(security.Stores(), ", "))
+	}
+
+	names := ui.GetThemeNames()
+
+	for i := range c.CustomThemes {
+		if err := c.CustomThemes[i].Validate(); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidFile, err.Error())
+		}
+
+		names = append(names, c.CustomThemes[i].Name)
+	}
+
+	dirThemes, _ := LoadThemesDir(fs.ThemesDir)
+
+	for _, theme := range dirThemes {
+		names = append(names, theme.Name)
+	}
+
+	if c.Theme != "" && !utils.Contains(names, c.Theme) {
+		return fmt.Errorf("%w: unknown theme '%s', expected one of: %s",
+			ErrInvalidFile, c.Theme, strings.Join(names, ", "))
+	}
+
+	return nil
+}
+
+// Sets the key in the content of a configuration file, the missing
+// sections are created along the way. The comments and the order of
+// the other keys are kept.
+func SetValue(content []byte, key string, value any) ([]byte, error) {
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidFile, err.Error())
+	}
+
+	// An empty document
+	if doc.Kind == 0 {
+		doc.Kind = yaml.DocumentNode
+	}
+
+	if len(doc.Content) == 0 {
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+
+	node := doc.Content[0]
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%w: the document isn't a mapping", ErrInvalidFile)
+	}
+
+	renameLegacyKeys(&doc)
+
+	parts := strings.Split(key, ".")
+
+	for i, part := range parts {
+		var next *yaml.Node
+
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if node.Content[j].Value == part {
+				next = node.Content[j+1]
+
+				break
+			}
+		}
+
+		if next == nil {
+			next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: part}, next)
+		}
+
+		if i == len(parts)-1 {
+			var encoded yaml.Node
+
+			if err := encoded.Encode(value); err != nil {
+				return nil, err
+			}
+
+			encoded.HeadComment, encoded.LineComment, encoded.FootComment = next.HeadComment, next.LineComment, next.FootComment
+			*next = encoded
+
+			break
+		}
+
+		// A section without keys, like 'editor:'
+		if next.Kind == yaml.ScalarNode && next.Tag == "!!null" {
+			next.Kind, next.Tag, next.Value = yaml.MappingNode, "!!map", ""
+		}
+
+		if next.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("key doesn't contain a section: %s", part)
+		}
+
+		node = next
+	}
+
+	return yaml.Marshal(&doc)
+}
+
+// Returns the content with the legacy keys renamed, as is if there's none.
+func withCurrentKeys(content []byte) ([]byte, error) {
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+
+	if !renameLegacyKeys(&doc) {
+		return content, nil
+	}
+
+	return yaml.Marshal(&doc)
+}
+
+// Renames the legacy keys of the document, unless the new ones are
+// also there. Reports whether any key was renamed.
+func renameLegacyKeys(doc *yaml.Node) bool {
+	if len(doc.Content) == 0 {
+		return false
+	}
+
+	var renamed bool
+
+	for old, current := range legacyKeys {
+		node, parts := doc.Content[0], strings.Split(old, ".")
+		name, newName := parts[len(parts)-1], current[strings.LastIndex(current, ".")+1:]
+
+		for _, part := range parts[:len(parts)-1] {
+			if node = mappingValue(node, part); node == nil {
+				break
+			}
+		}
+
+		if node == nil || mappingValue(node, newName) != nil {
+			continue
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == name {
+				node.Content[i].Value = newName
+				renamed = true
+			}
+		}
+	}
+
+	return renamed
+}
+
+// Returns the value of the key in the mapping node, nil if it isn't there.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/luisnquin/nao/v3/internal/config"
+)
+
+func TestParseValue(t *testing.T) {
+	checks := []struct {
+		key, raw string
+		fails    bool
+	}{
+		{key: "editor.name", raw: "vim"},
+		{key: "ls.keyLength", raw: "10"},
+		{key: "ls.keyLength", raw: "ten", fails: true},
+		{key: "ls.columns", raw: "[id, tag]"},
+		{key: "readOnlyOnConflict", raw: "maybe", fails: true},
+		{key: "editor", raw: "vim", fails: true},
+		{key: "edtor.name", raw: "vim", fails: true},
+		{key: "clipboard.clearAfter", raw: "30s"},
+		{key: "clipboard.clearAfter", raw: "soon", fails: true},
+		{key: "keyring.backend", raw: "pass"},
+	}
+
+	for _, check := range checks {
+		_, err := config.ParseValue(check.key, check.raw)
+		if (err != nil) != check.fails {
+			t.Errorf("unexpected result for %s=%s: %v", check.key, check.raw, err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	fs := config.FSConfig{ThemesDir: t.TempDir()}
+
+	if err := config.Validate(fs, config.DefaultFile); err != nil {
+		t.Errorf("the default configuration file should be valid: %v", err)
+	}
+
+	if err := config.Validate(fs, []byte("editor:\n  nme: vim\n")); !errors.Is(err, config.ErrInvalidFile) {
+		t.Errorf("expected %v with an unknown key, got %v", config.ErrInvalidFile, err)
+	}
+
+	if err := config.Validate(fs, []byte("editor:\n  name: emacs\n")); !errors.Is(err, config.ErrInvalidFile) {
+		t.Errorf("expected %v with an unsupported editor, got %v", config.ErrInvalidFile, err)
+	}
+
+	if err := config.Validate(fs, []byte("keyring:\n  backend: vault\n")); !errors.Is(err, config.ErrInvalidFile) {
+		t.Errorf("expected %v with an unsupported keyring backend, got %v", config.ErrInvalidFile, err)
+	}
+
+	if err := config.Validate(fs, []byte("ls:\n  NoColor: true\n")); err != nil {
+		t.Errorf("expected the legacy key to be valid, got %v", err)
+	}
+
+	if err := config.Validate(fs, []byte("theme: dracula\n")); !errors.Is(err, config.ErrInvalidFile) {
+		t.Errorf("expected %v with an unknown theme, got %v", config.ErrInvalidFile, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(fs.ThemesDir, "dracula.yml"), []byte("one: '#bd93f9'\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := config.Validate(fs, []byte("theme: dracula\n")); err != nil {
+		t.Errorf("expected the theme of the themes directory to be valid, got %v", err)
+	}
+}
+
+func TestSetValue(t *testing.T) {
+	content := "# My configuration\ntheme: nord # the best one\neditor:\n    # Possible values: nano, vim, nvim\n    name: vim\nlocale:\n"
+
+	checks := []struct {
+		key      string
+		value    any
+		expected string
+	}{
+		{
+			key: "editor.name", value: "nvim",
+			expected: "# My configuration\ntheme: nord # the best one\neditor:\n    # Possible values: nano, vim, nvim\n    name: nvim\nlocale:\n",
+		},
+		{
+			key: "theme", value: "party",
+			expected: "# My configuration\ntheme: party # the best one\neditor:\n    # Possible values: nano, vim, nvim\n    name: vim\nlocale:\n",
+		},
+		{
+			key: "clipboard.clearAfter", value: "30s",
+			expected: content + "clipboard:\n    clearAfter: 30s\n",
+		},
+	}
+
+	for _, check := range checks {
+		result, err := config.SetValue([]byte(content), check.key, check.value)
+		if err != nil {
+			t.Errorf("unexpected error with %s: %v", check.key, err)
+		}
+
+		if string(result) != check.expected {
+			t.Errorf("expected %q with %s, got %q", check.expected, check.key, result)
+		}
+	}
+
+	if result, err := config.SetValue(nil, "editor.name", "nano"); err != nil || string(result) != "editor:\n    name: nano\n" {
+		t.Errorf("unexpected result with an empty file: %q, %v", result, err)
+	}
+
+	if result, err := config.SetValue([]byte("editor:\n"), "editor.name", "nano"); err != nil || string(result) != "editor:\n    name: nano\n" {
+		t.Errorf("unexpected result with an empty section: %q, %v", result, err)
+	}
+
+	if result, err := config.SetValue([]byte("ls:\n    NoColor: true\n"), "ls.keyLength", 8); err != nil ||
+		string(result) != "ls:\n    noColor: true\n    keyLength: 8\n" {
+		t.Errorf("expected the legacy key to be renamed, got %q and %v", result, err)
+	}
+
+	if _, err := config.SetValue([]byte(content), "theme.name", "nord"); err == nil {
+		t.Error("expected an error when the key isn't a section")
+	}
+}
@@ -0,0 +1,286 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/luisnquin/nao/v3/internal/ui"
+	"gopkg.in/yaml.v3"
+)
+
+// The configuration is the result of merging these layers, each one
+// overriding the values of the previous ones:
+//
+//	defaults < system < user < project < env < flags
+//
+// The flags of every command are applied on top of the loaded
+// configuration by the command itself.
+const (
+	LayerDefault = "default"
+	LayerSystem  = "system"
+	LayerUser    = "user"
+	LayerProject = "project"
+	LayerEnv     = "env"
+	LayerFlag    = "flag"
+)
+
+const (
+	// The project-local configuration file, searched from the current
+	// working directory up to the root.
+	ProjectFileName = ".nao.yml"
+	SystemFile      = "/etc/nao/config.yml"
+	envPrefix       = "NAO_"
+)
+
+var ErrInvalidEnv = errors.New("invalid environment variable")
+
+// The keys that a project file can't set, since any parent directory, like
+// a cloned repository, could change where the secret of the data is stored
+// or what's executed.
+var projectRestrictedKeys = []string{"keyring", "clipboard", "editor"}
+
+// Where an effective value comes from.
+type Origin struct {
+	Layer string
+	// The file or the environment variable that sets the value, empty for defaults.
+	Source string
+}
+
+func (o Origin) String() string {
+	if o.Source == "" {
+		return o.Layer
+	}
+
+	return o.Layer + " (" + o.Source + ")"
+}
+
+// Returns the layer that sets the effective value of the key.
+func (c *Core) Origin(key string) Origin {
+	if origin, ok := c.origins[key]; ok {
+		return origin
+	}
+
+	return Origin{Layer: LayerDefault}
+}
+
+// Returns the name of the environment variable that overrides the
+// key, e.g. 'ls.keyLength' is overridden by NAO_LS_KEY_LENGTH.
+func EnvName(key string) string {
+	var b strings.Builder
+
+	b.WriteString(envPrefix)
+
+	runes := []rune(key)
+
+	for i, r := range runes {
+		switch {
+		case r == '.':
+			b.WriteRune('_')
+		case unicode.IsUpper(r) && i > 0 && unicode.IsLower(runes[i-1]):
+			b.WriteRune('_')
+			b.WriteRune(r)
+		default:
+			b.WriteRune(unicode.ToUpper(r))
+		}
+	}
+
+	return b.String()
+}
+
+// Decodes a layer over the current configuration and records the
+// origin of every key that it sets.
+func (c *Core) applyLayer(layer, source string, content []byte) error {
+	if layer == LayerProject {
+		var (
+			removed []string
+			err     error
+		)
+
+		content, removed, err = withoutRestrictedKeys(content)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range removed {
+			c.log.Warn().Str("key", key).Str("file", source).Msg("restricted key in project file, ignored")
+
+			ui.Warning(fmt.Sprintf("'%s' can't be set by the project file %s, ignored", key, source))
+		}
+	}
+
+	content, err := withCurrentKeys(content)
+	if err != nil {
+		return err
+	}
+
+	if err := yaml.Unmarshal(content, c); err != nil {
+		return err
+	}
+
+	var values map[string]any
+
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return err
+	}
+
+	known := make(map[string]bool)
+
+	for _, key := range Keys() {
+		known[key] = true
+	}
+
+	for _, key := range flattenKeys(values, "") {
+		if known[key] {
+			c.origins[key] = Origin{Layer: layer, Source: source}
+		}
+	}
+
+	return nil
+}
+
+// Reads the NAO_* environment variables and applies them as a layer.
+func (c *Core) applyEnv() error {
+	values := make(map[string]any)
+	sources := make(map[string]string)
+
+	for _, key := range Keys() {
+		name := EnvName(key)
+
+		raw, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		value, err := ParseValue(key, raw)
+		if err != nil {
+			return fmt.Errorf("%w %s: %s", ErrInvalidEnv, name, err.Error())
+		}
+
+		c.log.Trace().Str("env", name).Str("key", key).Msg("overriding key with environment variable")
+
+		setNested(values, strings.Split(key, "."), value)
+		sources[key] = name
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	content, err := yaml.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	if err := yaml.Unmarshal(content, c); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidEnv, err.Error())
+	}
+
+	for key, name := range sources {
+		c.origins[key] = Origin{Layer: LayerEnv, Source: name}
+	}
+
+	return nil
+}
+
+// Removes the restricted keys of the project layer from the content and
+// returns them.
+func withoutRestrictedKeys(content []byte) ([]byte, []string, error) {
+	var values map[string]any
+
+	if err := yaml.Unmarshal(content, &values); err != nil || values == nil {
+		return content, nil, err
+	}
+
+	var removed []string
+
+	for _, key := range flattenKeys(values, "") {
+		for _, restricted := range projectRestrictedKeys {
+			if key == restricted || strings.HasPrefix(key, restricted+".") {
+				deleteNested(values, strings.Split(key, "."))
+				removed = append(removed, key)
+
+				break
+			}
+		}
+	}
+
+	if len(removed) == 0 {
+		return content, nil, nil
+	}
+
+	content, err := yaml.Marshal(values)
+
+	return content, removed, err
+}
+
+// Walks up from the working directory looking for the project-local
+// configuration file.
+func findProjectFile() (string, bool) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		file := filepath.Join(dir, ProjectFileName)
+
+		if info, err := os.Stat(file); err == nil && !info.IsDir() {
+			return file, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+
+		dir = parent
+	}
+}
+
+func flattenKeys(values map[string]any, prefix string) []string {
+	var keys []string
+
+	for k, v := range values {
+		if nested, ok := v.(map[string]any); ok {
+			keys = append(keys, flattenKeys(nested, prefix+k+".")...)
+
+			continue
+		}
+
+		keys = append(keys, prefix+k)
+	}
+
+	return keys
+}
+
+func deleteNested(values map[string]any, path []string) {
+	if len(path) == 1 {
+		delete(values, path[0])
+
+		return
+	}
+
+	if nested, ok := values[path[0]].(map[string]any); ok {
+		deleteNested(nested, path[1:])
+	}
+}
+
+func setNested(values map[string]any, path []string, value any) {
+	if len(path) == 1 {
+		values[path[0]] = value
+
+		return
+	}
+
+	nested, ok := values[path[0]].(map[string]any)
+	if !ok {
+		nested = make(map[string]any)
+		values[path[0]] = nested
+	}
+
+	setNested(nested, path[1:], value)
+}
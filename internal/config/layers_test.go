@@ -0,0 +1,109 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/rs/zerolog"
+)
+
+func TestEnvName(t *testing.T) {
+	checks := map[string]string{
+		"theme":              "NAO_THEME",
+		"editor.extraArgs":   "NAO_EDITOR_EXTRA_ARGS",
+		"ls.keyLength":       "NAO_LS_KEY_LENGTH",
+		"readOnlyOnConflict": "NAO_READ_ONLY_ON_CONFLICT",
+	}
+
+	for key, expected := range checks {
+		if name := config.EnvName(key); name != expected {
+			t.Errorf("expected %s for '%s', got %s", expected, key, name)
+		}
+	}
+}
+
+func TestLayers(t *testing.T) {
+	home, project := t.TempDir(), t.TempDir()
+
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("NAO_THEME", "party")
+
+	write := func(file, content string) {
+		if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(filepath.Join(home, "nao", "config.yml"), "theme: nord\neditor:\n  name: vim\n  extraArgs: [-p]\nls:\n  NoColor: true\n")
+	write(filepath.Join(project, config.ProjectFileName),
+		"readOnlyOnConflict: true\neditor:\n  name: ./evil.sh\n  extraArgs: [+!sh]\nkeyring:\n  backend: env\n  variable: REPO_SECRET\n")
+
+	nested := filepath.Join(project, "a", "b")
+	if err := os.MkdirAll(nested, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chdir(nested); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Chdir(wd)
+
+	logger := zerolog.Nop()
+
+	c, err := config.New(&logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checks := []struct {
+		key, layer string
+		ok         bool
+	}{
+		{key: "readOnlyOnConflict", layer: config.LayerProject, ok: c.ReadOnlyOnConflict},
+		{key: "editor.extraArgs", layer: config.LayerUser, ok: len(c.Editor.ExtraArgs) == 1 && c.Editor.ExtraArgs[0] == "-p"},
+		{key: "keyring.backend", layer: config.LayerDefault, ok: c.Keyring.Backend == "keyring"},
+		{key: "keyring.variable", layer: config.LayerDefault, ok: c.Keyring.Variable == "NAO_SECRET"},
+		{key: "editor.name", layer: config.LayerUser, ok: c.Editor.Name == "vim"},
+		{key: "theme", layer: config.LayerEnv, ok: c.Theme == "party"},
+		{key: "ls.noColor", layer: config.LayerUser, ok: c.Command.Ls.NoColor},
+	}
+
+	for _, check := range checks {
+		if !check.ok {
+			t.Errorf("unexpected effective value for '%s'", check.key)
+		}
+
+		if origin := c.Origin(check.key); origin.Layer != check.layer {
+			t.Errorf("expected '%s' to come from %s, got %s", check.key, check.layer, origin)
+		}
+	}
+}
+
+func TestLayersUnsupportedEditor(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("NAO_EDITOR_NAME", "./evil.sh")
+
+	logger := zerolog.Nop()
+
+	c, err := config.New(&logger)
+	if !errors.Is(err, config.ErrInvalidFile) {
+		t.Errorf("expected %v, got %v", config.ErrInvalidFile, err)
+	}
+
+	if c.Editor.Name == "./evil.sh" {
+		t.Error("the unsupported editor shouldn't be kept")
+	}
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/luisnquin/nao/v3/internal/ui"
+	"gopkg.in/yaml.v3"
+)
+
+// A theme and the place where it's defined.
+type Theme struct {
+	*ui.ColorScheme
+	// Empty for the built-in themes.
+	Source string
+}
+
+// Returns the built-in themes followed by the ones in the themes
+// directory and the configuration file. The later ones replace the
+// themes with the same name.
+func (c *Core) Themes() []Theme {
+	themes := make([]Theme, 0, len(ui.GetThemeNames()))
+
+	for _, name := range ui.GetThemeNames() {
+		scheme, _ := ui.GetTheme(name)
+		themes = append(themes, Theme{ColorScheme: scheme})
+	}
+
+	custom := append([]Theme{}, c.dirThemes...)
+
+	for i := range c.CustomThemes {
+		scheme := c.CustomThemes[i]
+
+		if err := scheme.Validate(); err != nil {
+			c.log.Err(err).Msg("skipping theme from configuration file")
+
+			continue
+		}
+
+		custom = append(custom, Theme{ColorScheme: &scheme, Source: c.Origin("themes").Source})
+	}
+
+	for _, theme := range custom {
+		replaced := false
+
+		for i := range themes {
+			if themes[i].Name == theme.Name {
+				themes[i], replaced = theme, true
+
+				break
+			}
+		}
+
+		if !replaced {
+			themes = append(themes, theme)
+		}
+	}
+
+	return themes
+}
+
+// Returns the names of all the available themes.
+func (c *Core) ThemeNames() []string {
+	themes := c.Themes()
+	names := make([]string, len(themes))
+
+	for i, theme := range themes {
+		names[i] = theme.Name
+	}
+
+	return names
+}
+
+func (c *Core) FindTheme(name string) (Theme, bool) {
+	for _, theme := range c.Themes() {
+		if theme.Name == name {
+			return theme, true
+		}
+	}
+
+	return Theme{}, false
+}
+
+// Reads every YAML file in the directory as a theme, the name of the file
+// is used when the theme doesn't have one. The invalid files are skipped
+// and the first error is returned along with the rest of the themes.
+func LoadThemesDir(dir string) ([]Theme, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var (
+		themes   []Theme
+		firstErr error
+	)
+
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+
+		if entry.IsDir() || (ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+
+		file := filepath.Join(dir, entry.Name())
+
+		theme, err := LoadThemeFile(file)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+
+			continue
+		}
+
+		themes = append(themes, theme)
+	}
+
+	return themes, firstErr
+}
+
+func LoadThemeFile(file string) (Theme, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return Theme{}, err
+	}
+
+	scheme := new(ui.ColorScheme)
+
+	if err := yaml.Unmarshal(content, scheme); err != nil {
+		return Theme{}, fmt.Errorf("%w %s: %s", ui.ErrInvalidTheme, file, err.Error())
+	}
+
+	if scheme.Name == "" {
+		scheme.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+
+	if err := scheme.Validate(); err != nil {
+		return Theme{}, fmt.Errorf("%s: %w", file, err)
+	}
+
+	return Theme{ColorScheme: scheme, Source: file}, nil
+}
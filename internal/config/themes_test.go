@@ -0,0 +1,43 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/ui"
+)
+
+func TestLoadThemesDir(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"dracula.yml":  "one: '#bd93f9'\ntwo: '#ff79c6'\nthree: '212'\nfour: lightBlue\n",
+		"named.yaml":   "name: solarized\none: '#268bd2'\n",
+		"broken.yml":   "name: broken\none: '#zzzzzz'\n",
+		"ignored.json": "{}",
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	themes, err := config.LoadThemesDir(dir)
+	if !errors.Is(err, ui.ErrInvalidTheme) {
+		t.Errorf("expected %v because of the broken theme, got %v", ui.ErrInvalidTheme, err)
+	}
+
+	names := make(map[string]bool)
+
+	for _, theme := range themes {
+		names[theme.Name] = true
+	}
+
+	if len(themes) != 2 || !names["dracula"] || !names["solarized"] {
+		t.Errorf("expected dracula and solarized themes, got %v", names)
+	}
+}
@@ -0,0 +1,151 @@
+package data
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/luisnquin/nao/v3/internal"
+	"github.com/luisnquin/nao/v3/internal/models"
+	"github.com/luisnquin/nao/v3/internal/security"
+	"github.com/luisnquin/nao/v3/internal/utils"
+)
+
+var ErrCorruptedBlob = errors.New("corrupted attachment, the content doesn't match its hash")
+
+// The blobs written or reused recently aren't pruned, another process
+// could be writing them or about to save the note that uses them.
+const pruneGracePeriod = time.Hour
+
+// Stores the content of the attachments by its SHA-256, so the same
+// content is stored only once.
+type BlobStore struct {
+	Dir string
+	// Returns the secret to encrypt the blobs, they are stored as they
+	// are if nil.
+	Secret func() (string, error)
+}
+
+// Returns the blob store of the data directory, encrypted if the data is.
+func (b *Buffer) Blobs() BlobStore {
+	store := BlobStore{Dir: b.config.FS.BlobsDir}
+
+	if b.config.Encrypt {
+		store.Secret = func() (string, error) { return b.secret(false) }
+	}
+
+	return store
+}
+
+// Saves the content if there's no blob with the same hash and returns the hash.
+func (s BlobStore) Put(content []byte) (string, error) {
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+
+	if utils.FileExists(s.path(hash)) {
+		now := time.Now()
+
+		// Not pruned until the note is saved
+		return hash, os.Chtimes(s.path(hash), now, now)
+	}
+
+	if s.Secret != nil {
+		secret, err := s.Secret()
+		if err != nil {
+			return "", err
+		}
+
+		content, err = security.EncryptToAES256(content, secret)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if err := os.MkdirAll(s.Dir, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	// Renamed to not leave a partial blob behind if something fails
+	tmp := s.path(hash) + ".tmp"
+
+	if err := os.WriteFile(tmp, content, internal.PermReadWrite); err != nil {
+		return "", err
+	}
+
+	return hash, os.Rename(tmp, s.path(hash))
+}
+
+func (s BlobStore) Get(hash string) ([]byte, error) {
+	content, err := os.ReadFile(s.path(hash))
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Secret != nil {
+		secret, err := s.Secret()
+		if err != nil {
+			return nil, err
+		}
+
+		content, err = security.DecryptFromAES256(content, secret)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if sum := sha256.Sum256(content); hex.EncodeToString(sum[:]) != hash {
+		return nil, fmt.Errorf("%w: %s", ErrCorruptedBlob, hash)
+	}
+
+	return content, nil
+}
+
+// Deletes the blobs that aren't used by any note, along with the temporary
+// files left behind, unless they were modified in the grace period.
+func (s BlobStore) Prune(notes map[string]models.Note) error {
+	used := make(map[string]bool)
+
+	for _, n := range notes {
+		for _, a := range n.Attachments {
+			used[a.Hash] = true
+		}
+	}
+
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, entry := range entries {
+		if used[entry.Name()] {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		if time.Since(info.ModTime()) < pruneGracePeriod {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(s.Dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s BlobStore) path(hash string) string {
+	return filepath.Join(s.Dir, hash)
+}
@@ -0,0 +1,83 @@
+package data_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/models"
+	"github.com/luisnquin/nao/v3/internal/security"
+)
+
+func TestBlobStore(t *testing.T) {
+	secret := security.CreateRandomSecret()
+
+	store := data.BlobStore{
+		Dir:    t.TempDir(),
+		Secret: func() (string, error) { return secret, nil },
+	}
+
+	content := []byte("\x00\x01binary\xff")
+
+	hash, err := store.Put(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if again, err := store.Put(content); err != nil || again != hash {
+		t.Fatalf("expected the same hash, got %q and %v", again, err)
+	}
+
+	if entries, _ := os.ReadDir(store.Dir); len(entries) != 1 {
+		t.Fatalf("expected a single blob, got %d", len(entries))
+	}
+
+	stored, _ := os.ReadFile(filepath.Join(store.Dir, hash))
+	if string(stored) == string(content) {
+		t.Error("expected the blob to be encrypted")
+	}
+
+	if got, err := store.Get(hash); err != nil || string(got) != string(content) {
+		t.Fatalf("expected the original content, got %q and %v", got, err)
+	}
+
+	os.WriteFile(filepath.Join(store.Dir, hash), []byte("0123456789abcdef0123456789abcdef"), 0o600)
+
+	if _, err := store.Get(hash); !errors.Is(err, data.ErrCorruptedBlob) {
+		t.Errorf("expected a corrupted blob error, got %v", err)
+	}
+
+	notes := map[string]models.Note{"a": {Attachments: []models.Attachment{{Name: "x", Hash: hash}}}}
+
+	if err := store.Prune(notes); err != nil || !fileExists(filepath.Join(store.Dir, hash)) {
+		t.Fatalf("expected the used blob to be kept, %v", err)
+	}
+
+	tmp := filepath.Join(store.Dir, "in-flight.tmp")
+	os.WriteFile(tmp, content, 0o600)
+
+	if err := store.Prune(nil); err != nil || !fileExists(filepath.Join(store.Dir, hash)) || !fileExists(tmp) {
+		t.Fatalf("expected the recent files to be kept, %v", err)
+	}
+
+	old := time.Now().Add(-2 * time.Hour)
+
+	for _, file := range []string{filepath.Join(store.Dir, hash), tmp} {
+		if err := os.Chtimes(file, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := store.Prune(nil); err != nil || fileExists(filepath.Join(store.Dir, hash)) || fileExists(tmp) {
+		t.Errorf("expected the old unused files to be deleted, %v", err)
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+
+	return err == nil
+}
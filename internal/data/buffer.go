@@ -14,7 +14,6 @@ import (
 	"github.com/luisnquin/nao/v3/internal/security"
 	"github.com/luisnquin/nao/v3/internal/utils"
 	"github.com/rs/zerolog"
-	"github.com/zalando/go-keyring"
 )
 
 type (
@@ -23,6 +22,7 @@ type (
 		Metadata Metadata               `json:"metadata"`
 		log      *zerolog.Logger
 		config   *config.Core
+		secrets  security.SecretStore
 	}
 
 	Metadata struct {
@@ -30,6 +30,10 @@ type (
 		LastCreated KeyTag `json:"lastCreated,omitempty"`
 		// The key of the last accessed/modified note.
 		LastAccess KeyTag `json:"lastAccess,omitempty"`
+		// The recently accessed notes, from the most recent one.
+		Accessed []KeyTag `json:"accessed,omitempty"`
+		// The recently created notes, from the most recent one.
+		Created []KeyTag `json:"created,omitempty"`
 	}
 
 	KeyTag struct {
@@ -38,8 +42,60 @@ type (
 	}
 )
 
+// The maximum number of notes in the histories of the metadata.
+const HistorySize = 20
+
+// Sets the last accessed note and moves it to the top of the history.
+func (m *Metadata) RecordAccess(kt KeyTag) {
+	m.LastAccess = kt
+	m.Accessed = pushToHistory(m.Accessed, kt)
+}
+
+// Sets the last created note and moves it to the top of the history.
+func (m *Metadata) RecordCreation(kt KeyTag) {
+	m.LastCreated = kt
+	m.Created = pushToHistory(m.Created, kt)
+}
+
+// Returns the history of accessed notes, or of created notes if created
+// is true. The files without history only have the last note.
+func (m *Metadata) History(created bool) []KeyTag {
+	history, last := m.Accessed, m.LastAccess
+	if created {
+		history, last = m.Created, m.LastCreated
+	}
+
+	if len(history) == 0 && last.Key != "" {
+		return []KeyTag{last}
+	}
+
+	return history
+}
+
+func pushToHistory(history []KeyTag, kt KeyTag) []KeyTag {
+	result := make([]KeyTag, 1, HistorySize)
+	result[0] = kt
+
+	for _, item := range history {
+		if item.Key != kt.Key && len(result) < HistorySize {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
 func NewBuffer(logger *zerolog.Logger, config *config.Core) (*Buffer, error) {
-	data := Buffer{log: logger, config: config}
+	secrets, err := NewSecretStore(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return newBuffer(logger, config, secrets)
+}
+
+func newBuffer(logger *zerolog.Logger, config *config.Core, secrets security.SecretStore) (*Buffer, error) {
+	data := Buffer{log: logger, config: config, secrets: secrets}
 
 	if err := data.MigrateFileIfNeeded(); err != nil {
 		return nil, err
@@ -79,10 +135,9 @@ func (b *Buffer) MigrateFileIfNeeded() error {
 		}
 
 		if b.config.Encrypt {
-			secret := security.CreateRandomSecret()
-
-			if err := security.SetSecretInKeyring(secret); err != nil {
-				b.log.Err(err).Msg("failed attempt to set secret in keyring tool")
+			secret, err := b.secret(true)
+			if err != nil {
+				b.log.Err(err).Msg("failed attempt to set secret in the secret store")
 
 				return err
 			}
@@ -94,12 +149,12 @@ func (b *Buffer) MigrateFileIfNeeded() error {
 				return err
 			}
 		} else {
-			secret, err := security.GetSecretFromKeyring()
+			secret, err := b.secret(false)
 			if err != nil {
 				b.log.Err(err).
-					Msg("failed attempt to get secret from keyring tool, this means that probably the data is irrecoverable")
+					Msg("failed attempt to get secret from the secret store, this means that probably the data is irrecoverable")
 
-				if errors.Is(err, keyring.ErrNotFound) {
+				if errors.Is(err, security.ErrSecretNotFound) {
 					return errors.New("irrecoverable data file, secret not found")
 				}
 
@@ -113,9 +168,11 @@ func (b *Buffer) MigrateFileIfNeeded() error {
 				return err
 			}
 
-			b.log.Trace().Msg("deleting secret from keyring tool...")
+			b.log.Trace().Msg("deleting secret from the secret store...")
 
-			security.DeleteSecretFromKeyring()
+			if err := b.secrets.Delete(); err != nil {
+				b.log.Err(err).Msg("the secret couldn't be deleted")
+			}
 		}
 
 		b.log.Trace().Msg("data successfully recovered, the destiny file will be created and the other deleted")
@@ -194,7 +251,7 @@ func (b *Buffer) save() error {
 	}
 
 	if b.config.Encrypt {
-		secret, err := security.GetSecretFromKeyring()
+		secret, err := b.secret(b.isDataFileEmpty())
 		if err != nil {
 			return err
 		}
@@ -208,6 +265,14 @@ func (b *Buffer) save() error {
 	return ioutil.WriteFile(b.config.FS.DataFile(b.config.Encrypt), data, internal.PermReadWrite)
 }
 
+// Reports whether the data file doesn't exist or has just been created
+// by Reload, so there's no data encrypted with a previous secret.
+func (b *Buffer) isDataFileEmpty() bool {
+	info, err := os.Stat(b.config.FS.DataFile(b.config.Encrypt))
+
+	return errors.Is(err, os.ErrNotExist) || (err == nil && info.Size() == 0)
+}
+
 // First data load, if there's no file to load then it creates it.
 func (b *Buffer) Reload() error {
 	if err := b.Load(); err != nil {
@@ -250,8 +315,13 @@ func (b *Buffer) Load() error {
 		return err
 	}
 
-	if b.config.Encrypt {
-		secret, err := security.GetSecretFromKeyring()
+	// The file is empty when it has just been created
+	if b.config.Encrypt && len(data) > 0 {
+		secret, err := b.secret(false)
+		if errors.Is(err, security.ErrSecretNotFound) {
+			return fmt.Errorf("the data file is encrypted but the %s store has no secret: %w", b.config.Keyring.Backend, err)
+		}
+
 		if err != nil {
 			return err
 		}
@@ -262,9 +332,11 @@ func (b *Buffer) Load() error {
 		}
 	}
 
-	err = json.Unmarshal(data, b)
-	if err != nil && !errors.Is(err, io.EOF) {
-		return fmt.Errorf("unreadable json file: %w", err)
+	if len(data) > 0 {
+		err = json.Unmarshal(data, b)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("unreadable json file: %w", err)
+		}
 	}
 
 	if b.Notes == nil {
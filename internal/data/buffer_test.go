@@ -0,0 +1,60 @@
+package data_test
+
+import (
+	"errors"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/models"
+	"github.com/luisnquin/nao/v3/internal/security"
+	"github.com/rs/zerolog"
+)
+
+func TestEncryptedBuffer(t *testing.T) {
+	dir := t.TempDir()
+	logger := zerolog.Nop()
+
+	secrets := security.NewMemoryStore()
+
+	conf := &config.Core{
+		Encrypt: true,
+		FS: config.FSConfig{
+			DataEncryptedFile: path.Join(dir, "data.txt"),
+			DataNormalFile:    path.Join(dir, "data.json"),
+			DataDir:           dir,
+		},
+	}
+
+	buffer, err := data.NewBufferWithSecrets(&logger, conf, secrets)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buffer.Notes["abc123"] = models.Note{Tag: "wifi", Content: "hunter2"}
+
+	if err := buffer.Commit("abc123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if content, _ := os.ReadFile(conf.FS.DataEncryptedFile); strings.Contains(string(content), "hunter2") {
+		t.Error("expected the data file to be encrypted")
+	}
+
+	reloaded, err := data.NewBufferWithSecrets(&logger, conf, secrets)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if reloaded.Notes["abc123"].Content != "hunter2" {
+		t.Errorf("expected the note to be decrypted, got %+v", reloaded.Notes)
+	}
+
+	// A new secret would make the existing data unrecoverable
+	if _, err := data.NewBufferWithSecrets(&logger, conf, security.NewMemoryStore()); !errors.Is(err, security.ErrSecretNotFound) {
+		t.Errorf("expected %v with an empty store, got %v", security.ErrSecretNotFound, err)
+	}
+}
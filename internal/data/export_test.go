@@ -0,0 +1,12 @@
+package data
+
+import (
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/security"
+	"github.com/rs/zerolog"
+)
+
+// Like NewBuffer but with the provided secret store, e.g. a security.MemoryStore.
+func NewBufferWithSecrets(logger *zerolog.Logger, config *config.Core, secrets security.SecretStore) (*Buffer, error) {
+	return newBuffer(logger, config, secrets)
+}
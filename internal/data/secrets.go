@@ -0,0 +1,51 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/luisnquin/nao/v3/internal"
+	"github.com/luisnquin/nao/v3/internal/config"
+	"github.com/luisnquin/nao/v3/internal/security"
+	"github.com/luisnquin/nao/v3/internal/tui"
+)
+
+// Returns the secret store of the configuration, the system keyring by default.
+func NewSecretStore(config *config.Core) (security.SecretStore, error) {
+	switch config.Keyring.Backend {
+	case "", security.StoreKeyring:
+		return security.KeyringStore{Service: internal.AppName}, nil
+	case security.StoreFile:
+		return &security.FileStore{
+			Path: config.FS.KeystoreFile,
+			Passphrase: func() (string, error) {
+				return tui.ReadPassword("Passphrase of the keystore: ")
+			},
+			NewPassphrase: func() (string, error) {
+				return security.NewPassphrase(tui.ReadPassword, "New passphrase of the keystore: ")
+			},
+		}, nil
+	case security.StorePass:
+		return security.PassStore{Entry: config.Keyring.PassEntry}, nil
+	case security.StoreEnv:
+		return security.EnvStore{Variable: config.Keyring.Variable}, nil
+	}
+
+	return nil, fmt.Errorf("unknown keyring backend '%s'", config.Keyring.Backend)
+}
+
+// Returns the secret of the store, a new one is created and stored if
+// there's none and create is true. It must only be created along with
+// the data file, otherwise the existing data would be unrecoverable.
+func (b *Buffer) secret(create bool) (string, error) {
+	secret, err := b.secrets.Get()
+	if !errors.Is(err, security.ErrSecretNotFound) || !create {
+		return secret, err
+	}
+
+	b.log.Trace().Str("backend", b.config.Keyring.Backend).Msg("secret not found, creating a new one...")
+
+	secret = security.CreateRandomSecret()
+
+	return secret, b.secrets.Set(secret)
+}
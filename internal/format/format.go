@@ -0,0 +1,288 @@
+// Package format writes notes in formats meant for scripts, with raw values
+// instead of the human-readable ones displayed by the tables.
+package format
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/luisnquin/nao/v3/internal/models"
+	"github.com/luisnquin/nao/v3/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported formats, any other value is parsed as a Go template.
+const (
+	JSON   = "json"
+	CSV    = "csv"
+	NDJSON = "ndjson"
+	YAML   = "yaml"
+)
+
+var ErrUnknownFormat = errors.New("unknown format")
+
+func Kinds() []string {
+	return []string{JSON, CSV, NDJSON, YAML}
+}
+
+// Fields of the records.
+const (
+	FieldKey        = "key"
+	FieldTag        = "tag"
+	FieldSize       = "size"
+	FieldCreatedAt  = "createdAt"
+	FieldLastUpdate = "lastUpdate"
+	FieldTimeSpent  = "timeSpent"
+	FieldVersion    = "version"
+	FieldPicks      = "picks"
+	FieldAccessedAt = "lastAccessedAt"
+	FieldLines      = "lines"
+	FieldWords      = "words"
+	FieldPreview    = "preview"
+	FieldState      = "state"
+	FieldDueAt      = "dueAt"
+	// The number of attachments.
+	FieldAttachments = "attachments"
+	FieldContent     = "content"
+)
+
+// The fields written when none are selected.
+var DefaultFields = []string{
+	FieldKey, FieldTag, FieldSize, FieldCreatedAt, FieldLastUpdate,
+	FieldTimeSpent, FieldVersion, FieldPicks,
+}
+
+func Fields() []string {
+	return append(append([]string{}, DefaultFields...), FieldAccessedAt, FieldLines, FieldWords, FieldPreview, FieldState, FieldDueAt, FieldAttachments, FieldContent)
+}
+
+// A note with typed values: timestamps in RFC 3339, sizes in bytes and
+// durations in seconds. The fields keep the selected order.
+type Record struct {
+	fields []string
+	values []any
+}
+
+func NewRecord(n models.Note, fields []string) Record {
+	r := Record{fields: fields, values: make([]any, len(fields))}
+
+	for i, field := range fields {
+		r.values[i] = value(n, field)
+	}
+
+	return r
+}
+
+func value(n models.Note, field string) any {
+	switch field {
+	case FieldKey:
+		return n.Key
+	case FieldTag:
+		return n.Tag
+	case FieldSize:
+		return n.Size()
+	case FieldCreatedAt:
+		return n.CreatedAt.Format(time.RFC3339)
+	case FieldLastUpdate:
+		return n.LastUpdate.Format(time.RFC3339)
+	case FieldTimeSpent:
+		return int64(n.TimeSpent / time.Second)
+	case FieldVersion:
+		return n.Version
+	case FieldPicks:
+		return n.Picks
+	case FieldAccessedAt:
+		if n.LastAccessedAt.IsZero() {
+			return nil
+		}
+
+		return n.LastAccessedAt.Format(time.RFC3339)
+	case FieldLines:
+		return n.Lines()
+	case FieldWords:
+		return n.Words()
+	case FieldPreview:
+		return n.Preview(40)
+	case FieldDueAt:
+		if n.DueAt.IsZero() {
+			return nil
+		}
+
+		return n.DueAt.Format(time.RFC3339)
+	case FieldAttachments:
+		return len(n.Attachments)
+	case FieldState:
+		return strings.Join(n.States(), ",")
+	case FieldContent:
+		return n.RedactedContent()
+	}
+
+	return nil
+}
+
+func (r Record) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteByte('{')
+
+	for i, field := range r.fields {
+		if i != 0 {
+			b.WriteByte(',')
+		}
+
+		key, _ := json.Marshal(field)
+		value, err := json.Marshal(r.values[i])
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+
+	b.WriteByte('}')
+
+	return b.Bytes(), nil
+}
+
+func (r Record) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+
+	for i, field := range r.fields {
+		var value yaml.Node
+
+		if err := value.Encode(r.values[i]); err != nil {
+			return nil, err
+		}
+
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: field}, &value)
+	}
+
+	return node, nil
+}
+
+// Writes the selected fields of the notes in one of the supported formats,
+// or executes the format as a Go template for every note, e.g.
+// '{{.Tag}}\t{{.LastUpdate}}'. The default fields are used if none are provided.
+// The secrets of the content are always redacted.
+func Write(w io.Writer, format string, notes []models.Note, fields []string) error {
+	if len(fields) == 0 {
+		fields = DefaultFields
+	}
+
+	for _, field := range fields {
+		if !utils.Contains(Fields(), field) {
+			return fmt.Errorf("unknown field '%s', expected one of: %s", field, strings.Join(Fields(), ", "))
+		}
+	}
+
+	records := make([]Record, len(notes))
+
+	for i, n := range notes {
+		records[i] = NewRecord(n, fields)
+	}
+
+	switch format {
+	case JSON:
+		return json.NewEncoder(w).Encode(records)
+	case NDJSON:
+		encoder := json.NewEncoder(w)
+
+		for _, record := range records {
+			if err := encoder.Encode(record); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case YAML:
+		encoder := yaml.NewEncoder(w)
+
+		if err := encoder.Encode(records); err != nil {
+			return err
+		}
+
+		return encoder.Close()
+	case CSV:
+		return writeCSV(w, fields, records)
+	}
+
+	if !strings.Contains(format, "{{") {
+		if suggestion := utils.BestMatch(Kinds(), format); suggestion != "" {
+			return fmt.Errorf("%w '%s', did you mean '%s'?", ErrUnknownFormat, format, suggestion)
+		}
+
+		return fmt.Errorf("%w '%s', expected one of %s or a Go template", ErrUnknownFormat, format, strings.Join(Kinds(), ", "))
+	}
+
+	return writeTemplate(w, format, notes)
+}
+
+func writeCSV(w io.Writer, fields []string, records []Record) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(fields); err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		row := make([]string, len(r.values))
+
+		for j, value := range r.values {
+			if value != nil {
+				row[j] = fmt.Sprint(value)
+			}
+		}
+
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// Shells don't expand the escape sequences between single quotes.
+var escapes = strings.NewReplacer(`\t`, "\t", `\n`, "\n")
+
+func writeTemplate(w io.Writer, format string, notes []models.Note) error {
+	format = escapes.Replace(format)
+
+	if !strings.HasSuffix(format, "\n") {
+		format += "\n"
+	}
+
+	tmpl, err := template.New("format").Funcs(template.FuncMap{
+		"json": func(v any) (string, error) {
+			content, err := json.Marshal(v)
+
+			return string(content), err
+		},
+		"rfc3339": func(t time.Time) string {
+			return t.Format(time.RFC3339)
+		},
+	}).Parse(format)
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+
+	for _, n := range notes {
+		n.Content = n.RedactedContent()
+
+		if err := tmpl.Execute(w, n); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
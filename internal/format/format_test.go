@@ -0,0 +1,87 @@
+package format_test
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/luisnquin/nao/v3/internal/format"
+	"github.com/luisnquin/nao/v3/internal/models"
+)
+
+func TestWrite(t *testing.T) {
+	date := time.Date(2023, 5, 20, 12, 30, 0, 0, time.UTC)
+
+	notes := []models.Note{{
+		Key: "abc", Tag: "todo", Content: "x", CreatedAt: date, LastUpdate: date,
+		TimeSpent: 90 * time.Second, Version: 2, Picks: 5,
+	}}
+
+	size := strconv.Itoa(notes[0].Size())
+
+	checks := []struct {
+		format, out string
+		fields      []string
+	}{
+		{
+			format: format.NDJSON,
+			out: `{"key":"abc","tag":"todo","size":` + size + `,"createdAt":"2023-05-20T12:30:00Z",` +
+				`"lastUpdate":"2023-05-20T12:30:00Z","timeSpent":90,"version":2,"picks":5}` + "\n",
+		},
+		{
+			format: format.CSV,
+			out:    "key,tag,size,createdAt,lastUpdate,timeSpent,version,picks\nabc,todo," + size + ",2023-05-20T12:30:00Z,2023-05-20T12:30:00Z,90,2,5\n",
+		},
+		{format: format.CSV, fields: []string{format.FieldTag, format.FieldAccessedAt}, out: "tag,lastAccessedAt\ntodo,\n"},
+		{format: `{{.Tag}}\t{{.Version}}`, out: "todo\t2\n"},
+		{format: format.YAML, fields: []string{format.FieldWords, format.FieldTag}, out: "- words: 1\n  tag: todo\n"},
+		{format: `{{.Key}} {{rfc3339 .LastUpdate}}`, out: "abc 2023-05-20T12:30:00Z\n"},
+	}
+
+	for _, check := range checks {
+		var b bytes.Buffer
+
+		if err := format.Write(&b, check.format, notes, check.fields); err != nil {
+			t.Errorf("unexpected error with %q: %v", check.format, err)
+		}
+
+		if b.String() != check.out {
+			t.Errorf("expected %q with %q, got %q", check.out, check.format, b.String())
+		}
+	}
+
+	if err := format.Write(new(bytes.Buffer), "jsn", notes, nil); !errors.Is(err, format.ErrUnknownFormat) {
+		t.Errorf("expected %v, got %v", format.ErrUnknownFormat, err)
+	}
+}
+
+func TestWriteRedacted(t *testing.T) {
+	notes := []models.Note{
+		{Key: "abc", Tag: "db", Content: "user: root\npassword: [[secret:hunter2]]"},
+		{Key: "def", Tag: "keys", Content: "hunter2", Sensitive: true},
+	}
+
+	var b bytes.Buffer
+
+	if err := format.Write(&b, `{{.Tag}} {{json .Content}}`, notes, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "db \"user: root\\npassword: [REDACTED]\"\nkeys \"[REDACTED]\"\n"
+
+	if b.String() != expected {
+		t.Errorf("expected %q, got %q", expected, b.String())
+	}
+
+	b.Reset()
+
+	if err := format.Write(&b, format.CSV, notes[1:], []string{format.FieldContent}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expected := "content\n[REDACTED]\n"; b.String() != expected {
+		t.Errorf("expected %q, got %q", expected, b.String())
+	}
+}
@@ -25,6 +25,6 @@ const PermReadWrite = 0o600
 
 // Global flags.
 var (
-	NoColor bool
+	NoColor bool = os.Getenv("NO_COLOR") != ""
 	Debug   bool = utils.Contains(os.Args, "--debug")
 )
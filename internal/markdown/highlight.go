@@ -0,0 +1,196 @@
+package markdown
+
+import (
+	"strings"
+	"unicode"
+)
+
+type language struct {
+	keywords []string
+	comments []string
+}
+
+var languages = map[string]language{
+	"go": {
+		keywords: []string{
+			"break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough", "for", "func",
+			"go", "goto", "if", "import", "interface", "map", "package", "range", "return", "select", "struct",
+			"switch", "type", "var", "nil", "true", "false",
+		},
+		comments: []string{"//"},
+	},
+	"python": {
+		keywords: []string{
+			"and", "as", "assert", "async", "await", "break", "class", "continue", "def", "del", "elif", "else",
+			"except", "finally", "for", "from", "global", "if", "import", "in", "is", "lambda", "None", "nonlocal",
+			"not", "or", "pass", "raise", "return", "True", "False", "try", "while", "with", "yield",
+		},
+		comments: []string{"#"},
+	},
+	"javascript": {
+		keywords: []string{
+			"async", "await", "break", "case", "catch", "class", "const", "continue", "default", "delete", "do",
+			"else", "export", "extends", "false", "finally", "for", "function", "if", "import", "in", "instanceof",
+			"interface", "let", "new", "null", "return", "switch", "this", "throw", "true", "try", "type", "typeof",
+			"undefined", "var", "void", "while", "yield",
+		},
+		comments: []string{"//"},
+	},
+	"rust": {
+		keywords: []string{
+			"as", "async", "await", "break", "const", "continue", "crate", "else", "enum", "false", "fn", "for",
+			"if", "impl", "in", "let", "loop", "match", "mod", "move", "mut", "pub", "ref", "return", "self",
+			"Self", "static", "struct", "super", "trait", "true", "type", "unsafe", "use", "where", "while",
+		},
+		comments: []string{"//"},
+	},
+	"shell": {
+		keywords: []string{
+			"if", "then", "else", "elif", "fi", "for", "while", "until", "do", "done", "case", "esac", "in",
+			"function", "return", "local", "export", "echo", "exit",
+		},
+		comments: []string{"#"},
+	},
+	"sql": {
+		keywords: []string{
+			"select", "from", "where", "insert", "into", "values", "update", "set", "delete", "create", "table",
+			"drop", "alter", "join", "left", "right", "inner", "outer", "on", "group", "by", "order", "having",
+			"limit", "and", "or", "not", "null", "as", "distinct", "SELECT", "FROM", "WHERE", "INSERT", "INTO",
+			"VALUES", "UPDATE", "SET", "DELETE", "CREATE", "TABLE", "DROP", "ALTER", "JOIN", "LEFT", "RIGHT",
+			"INNER", "OUTER", "ON", "GROUP", "BY", "ORDER", "HAVING", "LIMIT", "AND", "OR", "NOT", "NULL", "AS",
+			"DISTINCT",
+		},
+		comments: []string{"--"},
+	},
+}
+
+var languageAliases = map[string]string{
+	"golang":     "go",
+	"py":         "python",
+	"js":         "javascript",
+	"ts":         "javascript",
+	"jsx":        "javascript",
+	"tsx":        "javascript",
+	"typescript": "javascript",
+	"rs":         "rust",
+	"sh":         "shell",
+	"bash":       "shell",
+	"zsh":        "shell",
+	"fish":       "shell",
+	"console":    "shell",
+}
+
+func lookupLanguage(name string) (language, bool) {
+	name = strings.ToLower(name)
+
+	if alias, ok := languageAliases[name]; ok {
+		name = alias
+	}
+
+	lang, ok := languages[name]
+
+	return lang, ok
+}
+
+// Highlights a line of code of the given language, unknown languages
+// only get their strings, numbers and common comments highlighted.
+func highlight(line, langName string, style Style) string {
+	lang, ok := lookupLanguage(langName)
+	if !ok {
+		lang = language{comments: []string{"//", "#"}}
+	}
+
+	var b strings.Builder
+
+	runes := []rune(line)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		if isComment(runes, i, lang.comments) {
+			b.WriteString(style.Comment.Sprint(string(runes[i:])))
+
+			break
+		}
+
+		switch {
+		case r == '"' || r == '\'' || r == '`':
+			end := i + 1
+
+			for end < len(runes) && runes[end] != r {
+				if runes[end] == '\\' {
+					end++
+				}
+
+				end++
+			}
+
+			if end >= len(runes) {
+				end = len(runes) - 1
+			}
+
+			b.WriteString(style.String.Sprint(string(runes[i : end+1])))
+			i = end + 1
+
+		case unicode.IsDigit(r) && (i == 0 || !isIdentRune(runes[i-1])):
+			end := i
+
+			for end < len(runes) && (unicode.IsDigit(runes[end]) || runes[end] == '.' || runes[end] == 'x' ||
+				runes[end] == '_' || unicode.Is(unicode.ASCII_Hex_Digit, runes[end])) {
+				end++
+			}
+
+			b.WriteString(style.Number.Sprint(string(runes[i:end])))
+			i = end
+
+		case isIdentRune(r):
+			end := i
+
+			for end < len(runes) && isIdentRune(runes[end]) {
+				end++
+			}
+
+			word := string(runes[i:end])
+
+			if contains(lang.keywords, word) {
+				b.WriteString(style.Keyword.Sprint(word))
+			} else {
+				b.WriteString(style.Code.Sprint(word))
+			}
+
+			i = end
+
+		default:
+			b.WriteRune(r)
+			i++
+		}
+	}
+
+	return b.String()
+}
+
+// A comment token only counts at the start of the line or after a space,
+// this avoids false positives like URLs.
+func isComment(runes []rune, i int, tokens []string) bool {
+	for _, token := range tokens {
+		if hasPrefix(runes, i, token) && (i == 0 || unicode.IsSpace(runes[i-1])) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func contains(words []string, target string) bool {
+	for _, w := range words {
+		if w == target {
+			return true
+		}
+	}
+
+	return false
+}
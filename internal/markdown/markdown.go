@@ -0,0 +1,407 @@
+// Package markdown renders Markdown documents for the terminal.
+package markdown
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/mattn/go-runewidth"
+)
+
+// Style holds the printers used for every element of the document.
+type Style struct {
+	Heading    color.PrinterFace
+	Subheading color.PrinterFace
+	Code       color.PrinterFace
+	Keyword    color.PrinterFace
+	String     color.PrinterFace
+	Comment    color.PrinterFace
+	Number     color.PrinterFace
+	Link       color.PrinterFace
+	Quote      color.PrinterFace
+	Bullet     color.PrinterFace
+	Rule       color.PrinterFace
+}
+
+// A style without colors, only the layout is rendered.
+func NoStyle() Style {
+	return Style{
+		Heading: color.Normal, Subheading: color.Normal, Code: color.Normal,
+		Keyword: color.Normal, String: color.Normal, Comment: color.Normal,
+		Number: color.Normal, Link: color.Normal, Quote: color.Normal,
+		Bullet: color.Normal, Rule: color.Normal,
+	}
+}
+
+var (
+	rxHeading      = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	rxFence        = regexp.MustCompile("^\\s*(```|~~~)\\s*([\\w+#.-]*)")
+	rxBullet       = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	rxOrdered      = regexp.MustCompile(`^(\s*)(\d+)[.)]\s+(.*)$`)
+	rxTask         = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+	rxRule         = regexp.MustCompile(`^\s*([-*_])(\s*[-*_]){2,}\s*$`)
+	rxQuote        = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	rxTableDivider = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	rxANSI         = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+)
+
+const (
+	bold   = "\x1b[1m"
+	italic = "\x1b[3m"
+	reset  = "\x1b[0m"
+)
+
+// Renders the Markdown source, the width is used for rules and
+// code blocks.
+func Render(source string, style Style, width int) string {
+	r := renderer{style: style, width: width}
+
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if m := rxFence.FindStringSubmatch(line); m != nil {
+			i = r.codeBlock(lines, i, m[1], m[2])
+
+			continue
+		}
+
+		if isTableRow(line) && i+1 < len(lines) && rxTableDivider.MatchString(lines[i+1]) && strings.Contains(lines[i+1], "-") {
+			i = r.table(lines, i)
+
+			continue
+		}
+
+		r.line(line)
+	}
+
+	return strings.TrimRight(r.out.String(), "\n") + "\n"
+}
+
+type renderer struct {
+	out   strings.Builder
+	style Style
+	width int
+}
+
+func (r *renderer) writeln(s string) {
+	r.out.WriteString(s)
+	r.out.WriteByte('\n')
+}
+
+func (r *renderer) line(line string) {
+	switch {
+	case rxHeading.MatchString(line):
+		m := rxHeading.FindStringSubmatch(line)
+
+		if len(m[1]) <= 2 {
+			r.writeln(bold + r.style.Heading.Sprint(m[1]+" "+m[2]))
+		} else {
+			r.writeln(bold + r.style.Subheading.Sprint(m[1]+" "+m[2]))
+		}
+
+	case rxRule.MatchString(line):
+		r.writeln(r.style.Rule.Sprint(strings.Repeat("─", r.width)))
+
+	case rxQuote.MatchString(line):
+		m := rxQuote.FindStringSubmatch(line)
+
+		r.writeln(r.style.Quote.Sprint("┃ ") + italic + r.inline(m[1]) + reset)
+
+	case rxBullet.MatchString(line):
+		m := rxBullet.FindStringSubmatch(line)
+
+		r.writeln(m[1] + r.listItem(r.style.Bullet.Sprint("•"), m[2]))
+
+	case rxOrdered.MatchString(line):
+		m := rxOrdered.FindStringSubmatch(line)
+
+		r.writeln(m[1] + r.listItem(r.style.Bullet.Sprint(m[2]+"."), m[3]))
+
+	default:
+		r.writeln(r.inline(line))
+	}
+}
+
+func (r *renderer) listItem(marker, text string) string {
+	if m := rxTask.FindStringSubmatch(text); m != nil {
+		if m[1] == " " {
+			return marker + " ☐ " + r.inline(m[2])
+		}
+
+		return marker + " " + r.style.Comment.Sprint("☑ "+m[2])
+	}
+
+	return marker + " " + r.inline(text)
+}
+
+// Renders the code block that starts in the given line and returns
+// the index of the closing fence.
+func (r *renderer) codeBlock(lines []string, start int, fence, lang string) int {
+	end := start + 1
+
+	for end < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[end]), fence) {
+		end++
+	}
+
+	if lang != "" {
+		r.writeln(r.style.Comment.Sprint("  " + lang))
+	}
+
+	for _, line := range lines[start+1 : minInt(end, len(lines))] {
+		r.writeln(r.style.Rule.Sprint("│ ") + highlight(line, lang, r.style))
+	}
+
+	return end
+}
+
+// Renders the table that starts in the given line and returns the
+// index of the last row.
+func (r *renderer) table(lines []string, start int) int {
+	header := splitRow(lines[start])
+	aligns := parseAlignments(splitRow(lines[start+1]))
+
+	rows := [][]string{header}
+	end := start + 2
+
+	for ; end < len(lines) && isTableRow(lines[end]); end++ {
+		rows = append(rows, splitRow(lines[end]))
+	}
+
+	columns := len(header)
+	rendered := make([][]string, len(rows))
+	widths := make([]int, columns)
+
+	for i, row := range rows {
+		rendered[i] = make([]string, columns)
+
+		for j := 0; j < columns; j++ {
+			var cell string
+
+			if j < len(row) {
+				cell = r.inline(row[j])
+			}
+
+			if i == 0 {
+				cell = bold + cell + reset
+			}
+
+			rendered[i][j] = cell
+
+			if w := visibleWidth(cell); w > widths[j] {
+				widths[j] = w
+			}
+		}
+	}
+
+	for i, row := range rendered {
+		cells := make([]string, columns)
+
+		for j, cell := range row {
+			align := byte('l')
+			if j < len(aligns) {
+				align = aligns[j]
+			}
+
+			cells[j] = pad(cell, widths[j], align)
+		}
+
+		r.writeln(strings.Join(cells, r.style.Rule.Sprint(" │ ")))
+
+		if i == 0 {
+			separators := make([]string, columns)
+
+			for j, w := range widths {
+				separators[j] = strings.Repeat("─", w)
+			}
+
+			r.writeln(r.style.Rule.Sprint(strings.Join(separators, "─┼─")))
+		}
+	}
+
+	return end - 1
+}
+
+// Applies the inline styles: code spans, bold, italic and links.
+func (r *renderer) inline(text string) string {
+	var b strings.Builder
+
+	runes := []rune(text)
+
+	for i := 0; i < len(runes); i++ {
+		switch {
+		case runes[i] == '\\' && i+1 < len(runes):
+			i++
+			b.WriteRune(runes[i])
+
+		case runes[i] == '`':
+			if end := indexRune(runes, i+1, '`'); end > 0 {
+				b.WriteString(r.style.Code.Sprint(string(runes[i+1 : end])))
+				i = end
+
+				continue
+			}
+
+			b.WriteRune(runes[i])
+
+		case hasPrefix(runes, i, "**") || hasPrefix(runes, i, "__"):
+			delimiter := string(runes[i : i+2])
+
+			if end := indexString(runes, i+2, delimiter); end > i+2 {
+				b.WriteString(bold + r.inline(string(runes[i+2:end])) + reset)
+				i = end + 1
+
+				continue
+			}
+
+			b.WriteString(delimiter)
+			i++
+
+		case (runes[i] == '*' || runes[i] == '_') && i+1 < len(runes) && runes[i+1] != ' ' &&
+			(i == 0 || !isWordRune(runes[i-1])):
+			if end := indexRune(runes, i+1, runes[i]); end > i+1 {
+				b.WriteString(italic + r.inline(string(runes[i+1:end])) + reset)
+				i = end
+
+				continue
+			}
+
+			b.WriteRune(runes[i])
+
+		case runes[i] == '[':
+			closing := indexString(runes, i+1, "](")
+			if closing > 0 {
+				if end := indexRune(runes, closing+2, ')'); end > 0 {
+					label, url := string(runes[i+1:closing]), string(runes[closing+2:end])
+
+					b.WriteString(r.style.Link.Sprint(label))
+
+					if url != label {
+						b.WriteString(" (" + r.style.Comment.Sprint(url) + ")")
+					}
+
+					i = end
+
+					continue
+				}
+			}
+
+			b.WriteRune(runes[i])
+
+		case runes[i] == '<' && (hasPrefix(runes, i+1, "http://") || hasPrefix(runes, i+1, "https://")):
+			if end := indexRune(runes, i+1, '>'); end > 0 {
+				b.WriteString(r.style.Link.Sprint(string(runes[i+1 : end])))
+				i = end
+
+				continue
+			}
+
+			b.WriteRune(runes[i])
+
+		default:
+			b.WriteRune(runes[i])
+		}
+	}
+
+	return b.String()
+}
+
+func isTableRow(line string) bool {
+	line = strings.TrimSpace(line)
+
+	return strings.HasPrefix(line, "|") && len(line) > 1
+}
+
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	line = strings.TrimSuffix(line, "|")
+
+	cells := strings.Split(line, "|")
+
+	for i, cell := range cells {
+		cells[i] = strings.TrimSpace(cell)
+	}
+
+	return cells
+}
+
+func parseAlignments(cells []string) []byte {
+	aligns := make([]byte, len(cells))
+
+	for i, cell := range cells {
+		switch {
+		case strings.HasPrefix(cell, ":") && strings.HasSuffix(cell, ":"):
+			aligns[i] = 'c'
+		case strings.HasSuffix(cell, ":"):
+			aligns[i] = 'r'
+		default:
+			aligns[i] = 'l'
+		}
+	}
+
+	return aligns
+}
+
+func pad(s string, width int, align byte) string {
+	gap := width - visibleWidth(s)
+	if gap <= 0 {
+		return s
+	}
+
+	switch align {
+	case 'r':
+		return strings.Repeat(" ", gap) + s
+	case 'c':
+		return strings.Repeat(" ", gap/2) + s + strings.Repeat(" ", gap-gap/2)
+	}
+
+	return s + strings.Repeat(" ", gap)
+}
+
+// Returns the number of terminal cells occupied by the string, ignoring
+// the color escape sequences.
+func visibleWidth(s string) int {
+	return runewidth.StringWidth(rxANSI.ReplaceAllString(s, ""))
+}
+
+func hasPrefix(runes []rune, i int, prefix string) bool {
+	return i <= len(runes) && strings.HasPrefix(string(runes[i:]), prefix)
+}
+
+func indexRune(runes []rune, from int, target rune) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == target {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func indexString(runes []rune, from int, target string) int {
+	if from > len(runes) {
+		return -1
+	}
+
+	i := strings.Index(string(runes[from:]), target)
+	if i < 0 {
+		return -1
+	}
+
+	return from + len([]rune(string(runes[from:])[:i]))
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9')
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
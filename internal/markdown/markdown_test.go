@@ -0,0 +1,38 @@
+package markdown_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/luisnquin/nao/v3/internal/markdown"
+)
+
+var rxANSI = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+func render(source string) string {
+	return rxANSI.ReplaceAllString(markdown.Render(source, markdown.NoStyle(), 10), "")
+}
+
+func TestRender(t *testing.T) {
+	checks := []struct {
+		in, out string
+	}{
+		{in: "# Title", out: "# Title\n"},
+		{in: "Some **bold**, *italic* and `code`", out: "Some bold, italic and code\n"},
+		{in: "snake_case_name", out: "snake_case_name\n"},
+		{in: "[nao](https://github.com/luisnquin/nao)", out: "nao (https://github.com/luisnquin/nao)\n"},
+		{in: "- one\n  * two", out: "• one\n  • two\n"},
+		{in: "1. first\n2) second", out: "1. first\n2. second\n"},
+		{in: "- [ ] todo\n- [x] done", out: "• ☐ todo\n• ☑ done\n"},
+		{in: "> quoted", out: "┃ quoted\n"},
+		{in: "***", out: "──────────\n"},
+		{in: "```go\nx := 1\n```", out: "  go\n│ x := 1\n"},
+		{in: "| a | bb |\n|:-|-:|\n| ccc | d |", out: "a   │ bb\n────┼───\nccc │  d\n"},
+	}
+
+	for _, expected := range checks {
+		if out := render(expected.in); out != expected.out {
+			t.Errorf("expected %q, but got %q from %q", expected.out, out, expected.in)
+		}
+	}
+}
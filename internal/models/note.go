@@ -1,8 +1,10 @@
 package models
 
 import (
+	"strings"
 	"time"
 
+	"github.com/luisnquin/nao/v3/internal/secrets"
 	"github.com/luisnquin/nao/v3/internal/utils"
 )
 
@@ -16,12 +18,146 @@ type Note struct {
 	TimeSpent  time.Duration `json:"timeSpent"`
 	// The number of get operations performed on a note.
 	Picks uint64 `json:"picks"`
+	// The time of the last get operation.
+	LastAccessedAt time.Time `json:"lastAccessedAt,omitempty"`
+	// Listed before the rest of the notes.
+	Pinned bool `json:"pinned,omitempty"`
+	// Hidden from the listings, the completions and the searches.
+	Archived bool `json:"archived,omitempty"`
+	Favorite bool `json:"favorite,omitempty"`
+	// The content is masked unless it's explicitly revealed.
+	Sensitive bool `json:"sensitive,omitempty"`
+	// The content is encrypted with a passphrase of the note.
+	Locked bool `json:"locked,omitempty"`
+	// When the note should be followed up, see 'nao due'.
+	DueAt       time.Time    `json:"dueAt,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
 }
 
+// A file stored alongside a note, its content is in the blob store.
+type Attachment struct {
+	Name string `json:"name"`
+	// The SHA-256 of the content.
+	Hash    string    `json:"hash"`
+	Size    int64     `json:"size"`
+	AddedAt time.Time `json:"addedAt"`
+}
+
+// Returns the size of the note, including its attachments.
 func (n *Note) Size() int {
-	return utils.GetSize(n)
+	size := utils.GetSize(n)
+
+	for _, a := range n.Attachments {
+		size += int(a.Size)
+	}
+
+	return size
 }
 
 func (n *Note) ReadableSize() string {
-	return utils.GetHumanReadableSize(n)
+	return utils.SizeToStorageUnits(n.Size())
+}
+
+// Returns the attachment with the name.
+func (n *Note) Attachment(name string) (Attachment, bool) {
+	for _, a := range n.Attachments {
+		if a.Name == name {
+			return a, true
+		}
+	}
+
+	return Attachment{}, false
+}
+
+// Returns the states of the note(pinned, favorite, archived, ...) that are set.
+func (n *Note) States() []string {
+	var states []string
+
+	if n.Pinned {
+		states = append(states, "pinned")
+	}
+
+	if n.Favorite {
+		states = append(states, "favorite")
+	}
+
+	if n.Archived {
+		states = append(states, "archived")
+	}
+
+	if n.Sensitive {
+		states = append(states, "sensitive")
+	}
+
+	if n.Locked {
+		states = append(states, "locked")
+	}
+
+	return states
+}
+
+// Reports whether the note has a due date before the time.
+func (n *Note) IsOverdue(now time.Time) bool {
+	return !n.DueAt.IsZero() && n.DueAt.Before(now)
+}
+
+// Returns the content to display, with the secret values masked or
+// fully masked if the note is sensitive or locked.
+func (n *Note) MaskedContent() string {
+	if n.Sensitive || n.Locked {
+		return secrets.Mask
+	}
+
+	return secrets.MaskValues(n.Content)
+}
+
+// Like MaskedContent but for exports, the secrets are replaced by a placeholder.
+func (n *Note) RedactedContent() string {
+	if n.Sensitive || n.Locked {
+		return secrets.Redacted
+	}
+
+	return secrets.Redact(n.Content)
+}
+
+// Returns the number of lines of the content, zero if it's locked.
+func (n *Note) Lines() int {
+	if n.Locked {
+		return 0
+	}
+
+	content := strings.TrimSuffix(n.Content, "\n")
+	if content == "" {
+		return 0
+	}
+
+	return strings.Count(content, "\n") + 1
+}
+
+// Returns the number of words of the content, zero if it's locked.
+func (n *Note) Words() int {
+	if n.Locked {
+		return 0
+	}
+
+	return len(strings.Fields(n.Content))
+}
+
+// Returns the first non-empty line of the masked content, truncated to
+// the provided number of characters.
+func (n *Note) Preview(length int) string {
+	for _, line := range strings.Split(n.MaskedContent(), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if runes := []rune(line); len(runes) > length {
+			return string(runes[:length]) + "…"
+		}
+
+		return line
+	}
+
+	return ""
 }
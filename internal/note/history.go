@@ -0,0 +1,77 @@
+package note
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/luisnquin/nao/v3/internal/data"
+)
+
+// References to the history: '@' is the last accessed note, '@new' the
+// last created one and '@~N' or '@new~N' the N-th previous one.
+var rxReference = regexp.MustCompile(`^@(new)?(?:~(\d+))?$`)
+
+// Returns the key of the note referenced from the history and whether the
+// argument is a reference. The notes with the argument as tag take
+// precedence over the references.
+func FromHistory(ref string, data *data.Buffer) (string, bool, error) {
+	matches := rxReference.FindStringSubmatch(ref)
+	if matches == nil || NewTagger(data).Exists(ref) {
+		return "", false, nil
+	}
+
+	position := 0
+
+	if matches[2] != "" {
+		n, err := strconv.Atoi(matches[2])
+		if err != nil {
+			return "", true, fmt.Errorf("%w: invalid reference '%s'", ErrNoteNotFound, ref)
+		}
+
+		position = n
+	}
+
+	keys := historyKeys(data, matches[1] != "")
+
+	if position >= len(keys) {
+		return "", true, fmt.Errorf("%w: there are only %d notes in the history of '%s'", ErrNoteNotFound, len(keys), ref)
+	}
+
+	return keys[position], true, nil
+}
+
+// Returns the available references to the history, like '@', '@~1' and '@new'.
+func HistoryReferences(data *data.Buffer) []string {
+	var refs []string
+
+	for _, created := range []bool{false, true} {
+		prefix := "@"
+		if created {
+			prefix = "@new"
+		}
+
+		for i := range historyKeys(data, created) {
+			if i == 0 {
+				refs = append(refs, prefix)
+			} else {
+				refs = append(refs, prefix+"~"+strconv.Itoa(i))
+			}
+		}
+	}
+
+	return refs
+}
+
+// The keys of the history without the deleted notes.
+func historyKeys(data *data.Buffer, created bool) []string {
+	var keys []string
+
+	for _, item := range data.Metadata.History(created) {
+		if _, ok := data.Notes[item.Key]; ok {
+			keys = append(keys, item.Key)
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,52 @@
+package note_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/note"
+)
+
+func TestFromHistory(t *testing.T) {
+	buffer := newBuffer()
+
+	for _, key := range []string{"ccc555", "deleted", "aaa111", "bbb333"} {
+		buffer.Metadata.RecordAccess(data.KeyTag{Key: key})
+	}
+
+	buffer.Metadata.RecordAccess(data.KeyTag{Key: "aaa111"}) // Moved to the top
+	buffer.Metadata.LastCreated = data.KeyTag{Key: "work44"}
+
+	checks := []struct {
+		ref, key string
+		notFound bool
+	}{
+		{ref: "@", key: "aaa111"},
+		{ref: "@~1", key: "bbb333"},
+		{ref: "@~2", key: "ccc555"}, // The deleted note is skipped
+		{ref: "@~3", notFound: true},
+		{ref: "@new", key: "work44"},
+		{ref: "@new~1", notFound: true},
+	}
+
+	for _, check := range checks {
+		key, err := note.Resolve(check.ref, buffer)
+
+		if check.notFound {
+			if !errors.Is(err, note.ErrNoteNotFound) {
+				t.Errorf("expected %v with '%s', got %s, %v", note.ErrNoteNotFound, check.ref, key, err)
+			}
+
+			continue
+		}
+
+		if err != nil || key != check.key {
+			t.Errorf("expected %s with '%s', got %s, %v", check.key, check.ref, key, err)
+		}
+	}
+
+	if _, ok, _ := note.FromHistory("@work", buffer); ok {
+		t.Error("'@work' shouldn't be a reference")
+	}
+}
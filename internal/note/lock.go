@@ -0,0 +1,48 @@
+package note
+
+import (
+	"github.com/luisnquin/nao/v3/internal/models"
+	"github.com/luisnquin/nao/v3/internal/security"
+)
+
+// Replaces the content by the one encrypted with Lock, the version isn't
+// changed since it's the same content.
+func WithLock(encrypted string) ModifyOption {
+	return func(n *models.Note) {
+		n.Content = encrypted
+		n.Locked = true
+	}
+}
+
+// Replaces the encrypted content by the plain one, see Unlock.
+func WithoutLock(content string) ModifyOption {
+	return func(n *models.Note) {
+		n.Content = content
+		n.Locked = false
+	}
+}
+
+// Encrypts the content with the passphrase, to be stored with WithLock or
+// WithContent if the note is already locked.
+func Lock(content, passphrase string) (string, error) {
+	encrypted, err := security.EncryptWithPassphrase([]byte(content), passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encrypted), nil
+}
+
+// Returns the plain content of the note, decrypted with the passphrase if it's locked.
+func Unlock(nt models.Note, passphrase string) (string, error) {
+	if !nt.Locked {
+		return nt.Content, nil
+	}
+
+	content, err := security.DecryptWithPassphrase([]byte(nt.Content), passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
@@ -2,4 +2,9 @@ package note
 
 import "errors"
 
-var ErrNoteNotFound = errors.New("note not found")
+var (
+	ErrNoteNotFound       = errors.New("note not found")
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrNoteLocked         = errors.New("note locked, unlock it with 'nao unlock'")
+	ErrNoteNotLocked      = errors.New("note not locked")
+)
@@ -0,0 +1,168 @@
+package note
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/luisnquin/nao/v3/internal/models"
+)
+
+// Fields to sort the notes by.
+const (
+	SortTag       = "tag"
+	SortCreated   = "created"
+	SortUpdated   = "updated"
+	SortSize      = "size"
+	SortVersion   = "version"
+	SortTimeSpent = "time-spent"
+	SortPicks     = "picks"
+	SortAccessed  = "accessed"
+	SortDue       = "due"
+)
+
+func SortFields() []string {
+	return []string{SortTag, SortCreated, SortUpdated, SortAccessed, SortSize, SortVersion, SortTimeSpent, SortPicks, SortDue}
+}
+
+// Filters, sorts and limits a list of notes. The zero value keeps
+// every note sorted by last update.
+type Query struct {
+	// One of SortFields, the tags are sorted alphabetically, the due
+	// dates from the soonest and the rest from the newest or biggest.
+	SortBy  string
+	Reverse bool
+	// No limit if zero.
+	Limit int
+	// The time range of the last update, or of the creation if ByCreation.
+	Since, Until time.Time
+	ByCreation   bool
+	TagPrefix    string
+	// Only the notes accessed at least once.
+	Accessed bool
+	// In bytes, no limit if zero.
+	MinSize, MaxSize int
+	// Only the notes with a due date, until DueUntil if not zero.
+	Due      bool
+	DueUntil time.Time
+	// Only the favorite notes.
+	Favorites bool
+	// The pinned notes go before the rest regardless of the order.
+	PinnedFirst bool
+}
+
+func (q Query) Apply(notes []models.Note) []models.Note {
+	result := make([]models.Note, 0, len(notes))
+
+	for _, n := range notes {
+		if q.matches(n) {
+			result = append(result, n)
+		}
+	}
+
+	less := q.less()
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if q.PinnedFirst && result[i].Pinned != result[j].Pinned {
+			return result[i].Pinned
+		}
+
+		if q.Reverse {
+			return less(result[j], result[i])
+		}
+
+		return less(result[i], result[j])
+	})
+
+	if q.Limit > 0 && len(result) > q.Limit {
+		result = result[:q.Limit]
+	}
+
+	return result
+}
+
+func (q Query) matches(n models.Note) bool {
+	date := n.LastUpdate
+	if q.ByCreation {
+		date = n.CreatedAt
+	}
+
+	if !q.Since.IsZero() && date.Before(q.Since) {
+		return false
+	}
+
+	if !q.Until.IsZero() && date.After(q.Until) {
+		return false
+	}
+
+	if q.Accessed && n.LastAccessedAt.IsZero() {
+		return false
+	}
+
+	if q.Due && (n.DueAt.IsZero() || (!q.DueUntil.IsZero() && n.DueAt.After(q.DueUntil))) {
+		return false
+	}
+
+	if q.Favorites && !n.Favorite {
+		return false
+	}
+
+	if q.TagPrefix != "" && !strings.HasPrefix(n.Tag, q.TagPrefix) {
+		return false
+	}
+
+	if q.MinSize > 0 || q.MaxSize > 0 {
+		size := n.Size()
+
+		if size < q.MinSize || (q.MaxSize > 0 && size > q.MaxSize) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (q Query) less() func(a, b models.Note) bool {
+	var less func(a, b models.Note) bool
+
+	switch q.SortBy {
+	case SortTag:
+		less = func(a, b models.Note) bool { return a.Tag < b.Tag }
+	case SortCreated:
+		less = func(a, b models.Note) bool { return a.CreatedAt.After(b.CreatedAt) }
+	case SortSize:
+		less = func(a, b models.Note) bool { return a.Size() > b.Size() }
+	case SortVersion:
+		less = func(a, b models.Note) bool { return a.Version > b.Version }
+	case SortTimeSpent:
+		less = func(a, b models.Note) bool { return a.TimeSpent > b.TimeSpent }
+	case SortPicks:
+		less = func(a, b models.Note) bool { return a.Picks > b.Picks }
+	case SortAccessed:
+		less = func(a, b models.Note) bool { return a.LastAccessedAt.After(b.LastAccessedAt) }
+	case SortDue:
+		// The notes without due date go last
+		less = func(a, b models.Note) bool {
+			if a.DueAt.IsZero() != b.DueAt.IsZero() {
+				return !a.DueAt.IsZero()
+			}
+
+			return a.DueAt.Before(b.DueAt)
+		}
+	default:
+		less = func(a, b models.Note) bool { return a.LastUpdate.After(b.LastUpdate) }
+	}
+
+	// The keys break the ties to have the same order in every call
+	return func(a, b models.Note) bool {
+		if less(a, b) {
+			return true
+		}
+
+		if less(b, a) {
+			return false
+		}
+
+		return a.Key < b.Key
+	}
+}
@@ -0,0 +1,53 @@
+package note_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luisnquin/nao/v3/internal/models"
+	"github.com/luisnquin/nao/v3/internal/note"
+)
+
+func TestQueryApply(t *testing.T) {
+	now := time.Now()
+
+	notes := []models.Note{
+		{Key: "a", Tag: "work-b", Version: 3, LastUpdate: now.Add(-time.Hour), CreatedAt: now.Add(-10 * time.Hour), Favorite: true},
+		{Key: "b", Tag: "home", Version: 1, LastUpdate: now.Add(-48 * time.Hour), CreatedAt: now.Add(-72 * time.Hour), Pinned: true, DueAt: now.Add(2 * time.Hour)},
+		{Key: "c", Tag: "work-a", Version: 3, LastUpdate: now.Add(-2 * time.Hour), CreatedAt: now.Add(-5 * time.Hour), DueAt: now.Add(-time.Hour)},
+	}
+
+	checks := []struct {
+		query    note.Query
+		expected string
+	}{
+		{query: note.Query{}, expected: "acb"},
+		{query: note.Query{Reverse: true}, expected: "bca"},
+		{query: note.Query{SortBy: note.SortTag}, expected: "bca"},
+		{query: note.Query{SortBy: note.SortCreated}, expected: "cab"},
+		{query: note.Query{SortBy: note.SortVersion}, expected: "acb"},
+		{query: note.Query{Limit: 2}, expected: "ac"},
+		{query: note.Query{TagPrefix: "work"}, expected: "ac"},
+		{query: note.Query{Since: now.Add(-24 * time.Hour)}, expected: "ac"},
+		{query: note.Query{Until: now.Add(-90 * time.Minute)}, expected: "cb"},
+		{query: note.Query{Since: now.Add(-6 * time.Hour), ByCreation: true}, expected: "c"},
+		{query: note.Query{MinSize: 1 << 20}, expected: ""},
+		{query: note.Query{Favorites: true}, expected: "a"},
+		{query: note.Query{SortBy: note.SortDue}, expected: "cba"},
+		{query: note.Query{Due: true, DueUntil: now}, expected: "c"},
+		{query: note.Query{PinnedFirst: true}, expected: "bac"},
+		{query: note.Query{PinnedFirst: true, Reverse: true}, expected: "bca"},
+	}
+
+	for _, check := range checks {
+		var keys string
+
+		for _, n := range check.query.Apply(notes) {
+			keys += n.Key
+		}
+
+		if keys != check.expected {
+			t.Errorf("expected %q with %+v, got %q", check.expected, check.query, keys)
+		}
+	}
+}
@@ -50,16 +50,133 @@ func WithContent(content string) ModifyOption {
 	}
 }
 
+func WithPinned(pinned bool) ModifyOption {
+	return func(n *models.Note) {
+		n.Pinned = pinned
+	}
+}
+
+func WithArchived(archived bool) ModifyOption {
+	return func(n *models.Note) {
+		n.Archived = archived
+	}
+}
+
+func WithFavorite(favorite bool) ModifyOption {
+	return func(n *models.Note) {
+		n.Favorite = favorite
+	}
+}
+
+func WithSensitive(sensitive bool) ModifyOption {
+	return func(n *models.Note) {
+		n.Sensitive = sensitive
+	}
+}
+
+// Copies the metadata of another note, like the dates, the version and the
+// time spent, but not the key, the tag or the content.
+func WithMetadataOf(other models.Note) ModifyOption {
+	return func(n *models.Note) {
+		n.CreatedAt = other.CreatedAt
+		n.LastUpdate = other.LastUpdate
+		n.Version = other.Version
+		n.TimeSpent = other.TimeSpent
+		n.Sensitive = other.Sensitive
+		n.DueAt = other.DueAt
+	}
+}
+
+// Sets the due date of the note, the zero time clears it.
+func WithDue(due time.Time) ModifyOption {
+	return func(n *models.Note) {
+		n.DueAt = due
+	}
+}
+
+// Adds the attachment, replacing the one with the same name.
+func WithAttachment(attachment models.Attachment) ModifyOption {
+	return func(n *models.Note) {
+		n.LastUpdate = time.Now()
+
+		for i, a := range n.Attachments {
+			if a.Name == attachment.Name {
+				n.Attachments[i] = attachment
+
+				return
+			}
+		}
+
+		n.Attachments = append(n.Attachments, attachment)
+	}
+}
+
+func WithoutAttachment(name string) ModifyOption {
+	return func(n *models.Note) {
+		attachments := make([]models.Attachment, 0, len(n.Attachments))
+
+		for _, a := range n.Attachments {
+			if a.Name != name {
+				attachments = append(attachments, a)
+			}
+		}
+
+		if len(attachments) != len(n.Attachments) {
+			n.LastUpdate = time.Now()
+		}
+
+		n.Attachments = attachments
+	}
+}
+
+// Which notes are listed according to their archived state.
+type Visibility int
+
+const (
+	// Every note except the archived ones.
+	Unarchived Visibility = iota
+	OnlyArchived
+	AllNotes
+)
+
+func (v Visibility) Includes(note models.Note) bool {
+	switch v {
+	case OnlyArchived:
+		return note.Archived
+	case AllNotes:
+		return true
+	default:
+		return !note.Archived
+	}
+}
+
+// Returns the note without recording the access.
+func (r NotesRepository) Peek(key string) (models.Note, error) {
+	note, ok := r.data.Notes[key]
+	if !ok {
+		return note, ErrNoteNotFound
+	}
+
+	note.Key = key
+
+	return note, nil
+}
+
+// Returns the note and records the access in it.
 func (r NotesRepository) Get(key string) (models.Note, error) {
 	note, ok := r.data.Notes[key]
 	if !ok {
 		return note, ErrNoteNotFound
 	}
 
-	r.data.Metadata.LastAccess = data.KeyTag{
+	r.data.Metadata.RecordAccess(data.KeyTag{
 		Tag: note.Tag,
 		Key: key,
-	}
+	})
+
+	note.Picks++
+	note.LastAccessedAt = time.Now()
+	r.data.Notes[key] = note
 
 	note.Key = key
 
@@ -91,10 +208,10 @@ func (r NotesRepository) New(content string, modifiers ...ModifyOption) (string,
 		key = utils.GenerateKey()
 	}
 
-	r.data.Metadata.LastCreated = data.KeyTag{
+	r.data.Metadata.RecordCreation(data.KeyTag{
 		Tag: note.Tag,
 		Key: key,
-	}
+	})
 
 	r.data.Notes[key] = note
 
@@ -116,21 +233,39 @@ func (r NotesRepository) Update(key string, modifiers ...ModifyOption) error {
 	return r.data.Commit(key)
 }
 
+// Deletes the note and the attachments that no other note uses.
 func (r NotesRepository) Delete(key string) error {
-	_, ok := r.data.Notes[key]
+	note, ok := r.data.Notes[key]
 	if !ok {
 		return ErrNoteNotFound
 	}
 
-	return r.data.Undo(key)
+	if err := r.data.Undo(key); err != nil {
+		return err
+	}
+
+	if len(note.Attachments) == 0 {
+		return nil
+	}
+
+	return r.data.Blobs().Prune(r.data.Notes)
 }
 
+// Returns the notes that aren't archived.
 func (r NotesRepository) Slice() []models.Note {
+	return r.SliceOf(Unarchived)
+}
+
+func (r NotesRepository) SliceOf(visibility Visibility) []models.Note {
 	notes := make([]models.Note, 0, len(r.data.Notes))
 
 	// TODO: autorepair key
 
 	for key, note := range r.data.Notes {
+		if !visibility.Includes(note) {
+			continue
+		}
+
 		note.Key = key
 
 		notes = append(notes, note)
@@ -140,20 +275,22 @@ func (r NotesRepository) Slice() []models.Note {
 }
 
 func (r NotesRepository) LastAccessed() (models.Note, error) {
-	note, ok := r.data.Notes[r.data.Metadata.LastAccess.Key]
-	if !ok {
-		return note, ErrNoteNotFound
+	if _, ok := r.data.Notes[r.data.Metadata.LastAccess.Key]; !ok {
+		return models.Note{}, ErrNoteNotFound
 	}
 
-	note.Key = r.data.Metadata.LastAccess.Key
-
-	return note, nil
+	return r.Get(r.data.Metadata.LastAccess.Key)
 }
 
+// Returns the keys of the notes that aren't archived.
 func (r NotesRepository) AllKeys() []string {
 	keys := make([]string, 0, len(r.data.Notes))
 
-	for key := range r.data.Notes {
+	for key, note := range r.data.Notes {
+		if note.Archived {
+			continue
+		}
+
 		keys = append(keys, key)
 	}
 
@@ -1,63 +1,212 @@
 package note
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/luisnquin/nao/v3/internal/data"
 	"github.com/luisnquin/nao/v3/internal/utils"
 )
 
+var ErrAmbiguousPrefix = errors.New("ambiguous prefix")
+
+// The kind of a match between a prefix and a note, the lower
+// the value the higher the priority.
+type MatchKind int
+
+const (
+	ExactTag MatchKind = iota
+	ExactKey
+	TagPrefix
+	KeyPrefix
+)
+
+type Match struct {
+	Key  string
+	Tag  string
+	Kind MatchKind
+}
+
+// AmbiguityError is returned when a prefix matches more than one
+// note with the same priority.
+type AmbiguityError struct {
+	Prefix     string
+	Candidates []Match
+}
+
+func (e *AmbiguityError) Error() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "'%s' matches %d notes:", e.Prefix, len(e.Candidates))
+
+	for _, c := range e.Candidates {
+		fmt.Fprintf(&b, "\n  %s (%s)", c.Tag, ShortKey(c.Key))
+	}
+
+	return b.String()
+}
+
+func (e *AmbiguityError) Is(target error) bool {
+	return target == ErrAmbiguousPrefix
+}
+
+// Returns the keys of the candidates.
+func (e *AmbiguityError) Keys() []string {
+	keys := make([]string, len(e.Candidates))
+
+	for i, c := range e.Candidates {
+		keys[i] = c.Key
+	}
+
+	return keys
+}
+
+// Returns the tags and short keys matched by the prefix, the archived
+// notes are excluded.
 func SearchKeyTagsByPrefix(prefix string, data *data.Buffer) []string {
+	return SearchKeyTagsOf(prefix, data, Unarchived)
+}
+
+func SearchKeyTagsOf(prefix string, data *data.Buffer, visibility Visibility) []string {
 	var results []string
 
 	for key, note := range data.Notes {
+		if !visibility.Includes(note) {
+			continue
+		}
+
 		if strings.HasPrefix(note.Tag, prefix) {
 			results = append(results, note.Tag)
 		}
 
 		if strings.HasPrefix(key, prefix) {
-			if len(key) >= 10 {
-				results = append(results, key[:10])
-			} else {
-				results = append(results, key)
-			}
+			results = append(results, ShortKey(key))
 		}
 	}
 
+	sort.Strings(results)
+
 	return results
 }
 
-func SearchByPrefix(prefix string, data *data.Buffer) (string, error) {
-	var result string
+// Returns all the notes matched by the prefix, ranked by the kind of
+// match and then by tag. A note appears only once, with its best kind.
+//
+// The archived notes aren't matched by a prefix of their tag.
+func Candidates(prefix string, data *data.Buffer) []Match {
+	var matches []Match
 
-	// We look for the pattern most similar to the availables keys/tags
 	for key, note := range data.Notes {
-		if strings.HasPrefix(note.Tag, prefix) && len(note.Tag) > len(result) ||
-			strings.HasPrefix(key, prefix) && len(key) > len(result) {
-			result = key
+		kind := MatchKind(-1)
+
+		switch {
+		case note.Tag == prefix:
+			kind = ExactTag
+		case key == prefix:
+			kind = ExactKey
+		case strings.HasPrefix(note.Tag, prefix):
+			kind = TagPrefix
+		case strings.HasPrefix(key, prefix):
+			kind = KeyPrefix
+		}
+
+		if note.Archived && kind == TagPrefix {
+			continue
+		}
 
-			if note.Tag == prefix || key == prefix {
-				break
-			}
+		if kind >= 0 {
+			matches = append(matches, Match{Key: key, Tag: note.Tag, Kind: kind})
 		}
 	}
 
-	// Your last bullet, I think
-	if result != "" {
-		return result, nil
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Kind != matches[j].Kind {
+			return matches[i].Kind < matches[j].Kind
+		}
+
+		if matches[i].Tag != matches[j].Tag {
+			return matches[i].Tag < matches[j].Tag
+		}
+
+		return matches[i].Key < matches[j].Key
+	})
+
+	return matches
+}
+
+// Returns the key of the note that unambiguously matches the prefix. The
+// candidates are ranked as: exact tag, exact key, unique tag prefix and
+// unique key prefix. An *AmbiguityError is returned if the best ranked
+// kind of match is shared by more than one note.
+//
+// The references to the history like '@' and '@new~1' are also resolved,
+// see FromHistory.
+func Resolve(prefix string, data *data.Buffer) (string, error) {
+	if key, ok, err := FromHistory(prefix, data); ok {
+		return key, err
+	}
+
+	candidates := Candidates(prefix, data)
+
+	if len(candidates) == 0 {
+		return "", notFound(prefix, data)
+	}
+
+	best := candidates[0]
+
+	if best.Kind == ExactTag || best.Kind == ExactKey {
+		return best.Key, nil
+	}
+
+	if best.Kind == TagPrefix && (len(candidates) == 1 || candidates[1].Kind != TagPrefix) {
+		return best.Key, nil
 	}
 
+	if best.Kind == KeyPrefix && len(candidates) == 1 {
+		return best.Key, nil
+	}
+
+	return "", &AmbiguityError{Prefix: prefix, Candidates: candidates}
+}
+
+// Like Resolve but, in case of ambiguity, returns the first ranked
+// candidate instead of an error.
+func SearchByPrefix(prefix string, data *data.Buffer) (string, error) {
+	key, err := Resolve(prefix, data)
+
+	var ambiguityErr *AmbiguityError
+
+	if errors.As(err, &ambiguityErr) {
+		return ambiguityErr.Candidates[0].Key, nil
+	}
+
+	return key, err
+}
+
+func notFound(prefix string, data *data.Buffer) error {
 	opts := make([]string, 0, len(data.Notes))
 
 	for _, n := range data.Notes {
 		opts = append(opts, n.Tag)
 	}
 
+	sort.Strings(opts)
+
 	bestMatch := utils.BestMatch(opts, prefix)
 	if bestMatch != "" {
-		return "", fmt.Errorf("key not found, did you mean '%s'?", bestMatch)
+		return fmt.Errorf("%w, did you mean '%s'?", ErrNoteNotFound, bestMatch)
+	}
+
+	return ErrNoteNotFound
+}
+
+// Returns the first ten characters of the key, as displayed to the user.
+func ShortKey(key string) string {
+	if len(key) >= 10 {
+		return key[:10]
 	}
 
-	return "", ErrNoteNotFound
+	return key
 }
@@ -0,0 +1,114 @@
+package note_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/luisnquin/nao/v3/internal/data"
+	"github.com/luisnquin/nao/v3/internal/models"
+	"github.com/luisnquin/nao/v3/internal/note"
+)
+
+func newBuffer() *data.Buffer {
+	return &data.Buffer{
+		Notes: map[string]models.Note{
+			"aaa111": {Tag: "work"},
+			"aaa222": {Tag: "workout"},
+			"bbb333": {Tag: "groceries"},
+			"work44": {Tag: "travel"},
+			"ccc555": {Tag: "ccc"},
+			"ccc666": {Tag: "books"},
+		},
+	}
+}
+
+func TestResolve(t *testing.T) {
+	buffer := newBuffer()
+
+	checks := []struct {
+		prefix, key string
+		ambiguous   bool
+	}{
+		{prefix: "work", key: "aaa111"},   // Exact tag over tag and key prefixes
+		{prefix: "ccc", key: "ccc555"},    // Exact tag over key prefix
+		{prefix: "aaa111", key: "aaa111"}, // Exact key
+		{prefix: "gro", key: "bbb333"},    // Unique tag prefix
+		{prefix: "bbb", key: "bbb333"},    // Unique key prefix
+		{prefix: "t", key: "work44"},      // Unique tag prefix over other kinds
+		{prefix: "wo", ambiguous: true},   // Two tag prefixes
+		{prefix: "aaa", ambiguous: true},  // Two key prefixes
+		{prefix: "workou", key: "aaa222"}, // Unique tag prefix
+		{prefix: "b", key: "ccc666"},      // Unique tag prefix over key prefix
+		{prefix: "cc", key: "ccc555"},     // Unique tag prefix, the other note only by key
+	}
+
+	for _, expected := range checks {
+		key, err := note.Resolve(expected.prefix, buffer)
+
+		if expected.ambiguous {
+			if !errors.Is(err, note.ErrAmbiguousPrefix) {
+				t.Errorf("expected ambiguity error for '%s', but got '%s' and %v", expected.prefix, key, err)
+			}
+
+			continue
+		}
+
+		if err != nil || key != expected.key {
+			t.Errorf("expected '%s' for '%s', but got '%s' and %v", expected.key, expected.prefix, key, err)
+		}
+	}
+}
+
+func TestSearchByPrefixIsDeterministic(t *testing.T) {
+	buffer := newBuffer()
+
+	for i := 0; i < 50; i++ {
+		key, err := note.SearchByPrefix("wo", buffer)
+		if err != nil || key != "aaa111" {
+			t.Fatalf("expected 'aaa111', but got '%s' and %v", key, err)
+		}
+	}
+
+	var ambiguityErr *note.AmbiguityError
+
+	_, err := note.Resolve("aaa", buffer)
+	if !errors.As(err, &ambiguityErr) {
+		t.Fatalf("expected an ambiguity error, got %v", err)
+	}
+
+	if keys := ambiguityErr.Keys(); len(keys) != 2 || keys[0] != "aaa111" || keys[1] != "aaa222" {
+		t.Errorf("unexpected candidates %v", keys)
+	}
+}
+
+func TestArchivedNotes(t *testing.T) {
+	buffer := newBuffer()
+
+	archived := buffer.Notes["bbb333"]
+	archived.Archived = true
+	buffer.Notes["bbb333"] = archived
+
+	repo := note.NewRepository(buffer)
+
+	if notes := repo.Slice(); len(notes) != len(buffer.Notes)-1 {
+		t.Errorf("expected %d notes, got %d", len(buffer.Notes)-1, len(notes))
+	}
+
+	if notes := repo.SliceOf(note.OnlyArchived); len(notes) != 1 || notes[0].Key != "bbb333" {
+		t.Errorf("expected only the archived note, got %v", notes)
+	}
+
+	if results := note.SearchKeyTagsByPrefix("gro", buffer); len(results) != 0 {
+		t.Errorf("expected no completions, got %v", results)
+	}
+
+	if _, err := note.Resolve("gro", buffer); err == nil {
+		t.Error("expected the archived note to be hidden from the tag prefixes")
+	}
+
+	for _, ref := range []string{"groceries", "bbb333", "bbb"} {
+		if key, err := note.Resolve(ref, buffer); err != nil || key != "bbb333" {
+			t.Errorf("expected 'bbb333' for '%s', but got '%s' and %v", ref, key, err)
+		}
+	}
+}
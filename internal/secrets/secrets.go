@@ -0,0 +1,75 @@
+// Package secrets masks the secret values of the notes, written as
+// [[secret:value]], and detects the credentials that aren't marked.
+package secrets
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The replacement of the secret values in the masked content.
+const (
+	Mask     = "••••••••"
+	Redacted = "[REDACTED]"
+)
+
+var rxMarker = regexp.MustCompile(`\[\[secret:([^\]\n]*)\]\]`)
+
+// Replaces the secret values with the mask.
+func MaskValues(content string) string {
+	return rxMarker.ReplaceAllLiteralString(content, Mask)
+}
+
+// Replaces the secret values with the redacted placeholder, meant for exports.
+func Redact(content string) string {
+	return rxMarker.ReplaceAllLiteralString(content, Redacted)
+}
+
+// Removes the markers, keeping the secret values.
+func Reveal(content string) string {
+	return rxMarker.ReplaceAllString(content, "$1")
+}
+
+// Reports whether the content has secret values.
+func HasMarkers(content string) bool {
+	return rxMarker.MatchString(content)
+}
+
+// A value that looks like a credential.
+type Finding struct {
+	Line int    `json:"line"`
+	Kind string `json:"kind"`
+}
+
+var detectors = []struct {
+	kind string
+	rx   *regexp.Regexp
+}{
+	{"private key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)},
+	{"AWS access key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"GitHub token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{40,})\b`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{"Stripe key", regexp.MustCompile(`\b[rs]k_live_[A-Za-z0-9]{16,}\b`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{"JSON web token", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]+`)},
+	{"credentials in URL", regexp.MustCompile(`[a-z][a-z0-9+.-]*://[^\s:/@]+:[^\s:/@]+@`)},
+	{"password", regexp.MustCompile(`(?i)\b(?:password|passwd|pwd|secret|token|api[_-]?key)\b\s*[:=]\s*\S{4,}`)},
+}
+
+// Returns the lines that look like they contain credentials, the
+// marked secret values are ignored.
+func Scan(content string) []Finding {
+	var findings []Finding
+
+	for i, line := range strings.Split(rxMarker.ReplaceAllLiteralString(content, ""), "\n") {
+		for _, d := range detectors {
+			if d.rx.MatchString(line) {
+				findings = append(findings, Finding{Line: i + 1, Kind: d.kind})
+
+				break
+			}
+		}
+	}
+
+	return findings
+}
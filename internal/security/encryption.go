@@ -44,6 +44,10 @@ func EncryptAndEncode(plainText []byte, key string) ([]byte, error) {
 
 func DecryptFromAES256(encryptedText []byte, key string) ([]byte, error) {
 	// iv is always stored in the encrypted text
+	if len(encryptedText) < aes.BlockSize {
+		return nil, errors.New("ciphertext too short")
+	}
+
 	iv := encryptedText[:aes.BlockSize]
 	encryptedText = encryptedText[aes.BlockSize:]
 
@@ -52,10 +56,6 @@ func DecryptFromAES256(encryptedText []byte, key string) ([]byte, error) {
 		return nil, err
 	}
 
-	if len(encryptedText) < aes.BlockSize { // ? maybe remove/improve this
-		return nil, errors.New("ciphertext too short")
-	}
-
 	plainText := make([]byte, len(encryptedText))
 
 	cipher.NewCFBDecrypter(block, iv).XORKeyStream(plainText, encryptedText)
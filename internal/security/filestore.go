@@ -0,0 +1,101 @@
+package security
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/luisnquin/nao/v3/internal"
+)
+
+// Stores the secret in a file, encrypted with a passphrase.
+type FileStore struct {
+	Path string
+	// Prompts for the passphrase, it's called once at most.
+	Passphrase func() (string, error)
+	// Prompts for the passphrase of a new keystore, it should be asked twice
+	// to confirm it. Passphrase is used if nil.
+	NewPassphrase func() (string, error)
+
+	mu         sync.Mutex
+	passphrase string
+}
+
+func (s *FileStore) Get() (string, error) {
+	content, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrSecretNotFound
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	passphrase, err := s.getPassphrase(s.Passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := DecryptWithPassphrase(content, passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	return string(secret), nil
+}
+
+func (s *FileStore) Set(secret string) error {
+	prompt := s.Passphrase
+
+	if _, err := os.Stat(s.Path); errors.Is(err, os.ErrNotExist) && s.NewPassphrase != nil {
+		prompt = s.NewPassphrase
+	}
+
+	passphrase, err := s.getPassphrase(prompt)
+	if err != nil {
+		return err
+	}
+
+	content, err := EncryptWithPassphrase([]byte(secret), passphrase)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.Path), os.ModePerm); err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.Path, content, internal.PermReadWrite)
+}
+
+func (s *FileStore) Delete() error {
+	err := os.Remove(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrSecretNotFound
+	}
+
+	return err
+}
+
+func (s *FileStore) getPassphrase(prompt func() (string, error)) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.passphrase != "" {
+		return s.passphrase, nil
+	}
+
+	passphrase, err := prompt()
+	if err != nil {
+		return "", err
+	}
+
+	if passphrase == "" {
+		return "", ErrEmptyPassphrase
+	}
+
+	s.passphrase = passphrase
+
+	return passphrase, nil
+}
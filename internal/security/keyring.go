@@ -1,50 +1,70 @@
 package security
 
 import (
+	"errors"
+	"fmt"
 	"os/user"
 
-	"github.com/luisnquin/nao/v3/internal"
 	"github.com/zalando/go-keyring"
 )
 
-// Retrieves a secret stored in the system keyring. It uses the current
-// username of the caller and the app name to access the secret.
-//
-// If the current username can't be determined, the function panics
-// with an error.
-func GetSecretFromKeyring() (string, error) {
-	caller, err := user.Current()
+// Stores the secret in the system keyring, under the service and the
+// current username of the caller.
+type KeyringStore struct {
+	Service string
+}
+
+func (s KeyringStore) Get() (string, error) {
+	username, err := currentUsername()
 	if err != nil {
-		panic(err)
+		return "", err
+	}
+
+	secret, err := keyring.Get(s.Service, username)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrSecretNotFound
 	}
 
-	return keyring.Get(internal.AppName, caller.Username)
+	return secret, keyringError(err)
 }
 
-// Stores a secret in the system keyring. It uses the current username
-// of the caller and the app name to access the secret.
-//
-// If the current username can't be determined, the function panics
-// with an error.
-func SetSecretInKeyring(secret string) error {
-	caller, err := user.Current()
+func (s KeyringStore) Set(secret string) error {
+	username, err := currentUsername()
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	return keyring.Set(internal.AppName, caller.Username, secret)
+	return keyringError(keyring.Set(s.Service, username, secret))
 }
 
-// Deletes a secret stored in the system keyring. It uses the current
-// username of the caller and the app name to access the secret.
-//
-// If the current username can't be determined, the function panics
-// with an error.
-func DeleteSecretFromKeyring() error {
+func (s KeyringStore) Delete() error {
+	username, err := currentUsername()
+	if err != nil {
+		return err
+	}
+
+	err = keyring.Delete(s.Service, username)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return ErrSecretNotFound
+	}
+
+	return keyringError(err)
+}
+
+func currentUsername() (string, error) {
 	caller, err := user.Current()
 	if err != nil {
-		panic(err)
+		return "", fmt.Errorf("unable to determine the current user for the keyring: %w", err)
+	}
+
+	return caller.Username, nil
+}
+
+// The system keyring isn't always available, e.g. without D-Bus on Linux.
+func keyringError(err error) error {
+	if err == nil {
+		return nil
 	}
 
-	return keyring.Delete(internal.AppName, caller.Username)
+	return fmt.Errorf("system keyring: %w, another secret store can be set with 'nao config set keyring.backend <store>'", err)
 }
@@ -0,0 +1,75 @@
+package security
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Stores the secret in an entry of pass, the standard unix password manager,
+// encrypted with the GPG key of its store.
+type PassStore struct {
+	Entry string
+}
+
+func (s PassStore) Get() (string, error) {
+	out, err := s.run(nil, "show", s.Entry)
+	if err != nil {
+		return "", err
+	}
+
+	// The first line is the password
+	secret := strings.SplitN(string(out), "\n", 2)[0]
+	if secret == "" {
+		return "", ErrSecretNotFound
+	}
+
+	return secret, nil
+}
+
+func (s PassStore) Set(secret string) error {
+	_, err := s.run(strings.NewReader(secret+"\n"), "insert", "--multiline", "--force", s.Entry)
+
+	return err
+}
+
+func (s PassStore) Delete() error {
+	_, err := s.run(nil, "rm", "--force", s.Entry)
+
+	return err
+}
+
+func (s PassStore) run(stdin *strings.Reader, args ...string) ([]byte, error) {
+	if _, err := exec.LookPath("pass"); err != nil {
+		return nil, fmt.Errorf("pass: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command("pass", args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+
+		var exitErr *exec.ExitError
+
+		if errors.As(err, &exitErr) && strings.Contains(message, "is not in the password store") {
+			return nil, ErrSecretNotFound
+		}
+
+		if message != "" {
+			return nil, fmt.Errorf("pass %s: %s", args[0], message)
+		}
+
+		return nil, fmt.Errorf("pass %s: %w", args[0], err)
+	}
+
+	return stdout.Bytes(), nil
+}
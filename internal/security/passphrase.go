@@ -0,0 +1,116 @@
+package security
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// The number of PBKDF2 iterations to derive the keys from the passphrases.
+	passphraseIterations = 210000
+	passphraseSaltSize   = 16
+	passphraseKeySize    = 32
+)
+
+var (
+	ErrWrongPassphrase    = errors.New("wrong passphrase or corrupted content")
+	ErrEmptyPassphrase    = errors.New("empty passphrase")
+	ErrPassphraseMismatch = errors.New("the passphrases don't match")
+)
+
+// Reads a new passphrase twice with the prompt, so a typo doesn't make
+// the content encrypted with it unrecoverable.
+func NewPassphrase(read func(prompt string) (string, error), prompt string) (string, error) {
+	passphrase, err := read(prompt)
+	if err != nil {
+		return "", err
+	}
+
+	if passphrase == "" {
+		return "", ErrEmptyPassphrase
+	}
+
+	confirmation, err := read("Repeat the passphrase: ")
+	if err != nil {
+		return "", err
+	}
+
+	if confirmation != passphrase {
+		return "", ErrPassphraseMismatch
+	}
+
+	return passphrase, nil
+}
+
+// Derives a key from the passphrase by using PBKDF2 with HMAC-SHA256.
+func DeriveKey(passphrase string, salt []byte, iterations, size int) []byte {
+	return pbkdf2.Key([]byte(passphrase), salt, iterations, size, sha256.New)
+}
+
+// Encrypts the content with a key derived from the passphrase by using AES-256-GCM,
+// the result is the salt, the nonce and the ciphertext encoded in std base64.
+func EncryptWithPassphrase(plainText []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, passphraseSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	gcm, err := passphraseCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	sealed := append(append([]byte{}, salt...), nonce...)
+
+	return EncodeToBase64(gcm.Seal(sealed, nonce, plainText, nil)), nil
+}
+
+// Decrypts the content encrypted by EncryptWithPassphrase, the passphrase is
+// verified thanks to the authentication of GCM.
+func DecryptWithPassphrase(encryptedText []byte, passphrase string) ([]byte, error) {
+	sealed, err := DecodeFromBase64(encryptedText)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < passphraseSaltSize {
+		return nil, ErrWrongPassphrase
+	}
+
+	gcm, err := passphraseCipher(passphrase, sealed[:passphraseSaltSize])
+	if err != nil {
+		return nil, err
+	}
+
+	sealed = sealed[passphraseSaltSize:]
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, ErrWrongPassphrase
+	}
+
+	plainText, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	return plainText, nil
+}
+
+func passphraseCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(DeriveKey(passphrase, salt, passphraseIterations, passphraseKeySize))
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,43 @@
+package security_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/luisnquin/nao/v3/internal/security"
+)
+
+func TestDeriveKey(t *testing.T) {
+	// From RFC 7914, section 11
+	expected := "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc" +
+		"49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"
+
+	if key := hex.EncodeToString(security.DeriveKey("passwd", []byte("salt"), 1, 64)); key != expected {
+		t.Errorf("expected %s, got %s", expected, key)
+	}
+}
+
+func TestPassphrase(t *testing.T) {
+	encrypted, err := security.EncryptWithPassphrase([]byte("the wifi password"), "correct horse")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, err := security.DecryptWithPassphrase(encrypted, "correct horse")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(content) != "the wifi password" {
+		t.Errorf("expected the original content, got %q", content)
+	}
+
+	if _, err := security.DecryptWithPassphrase(encrypted, "battery staple"); !errors.Is(err, security.ErrWrongPassphrase) {
+		t.Errorf("expected %v, got %v", security.ErrWrongPassphrase, err)
+	}
+
+	if _, err := security.DecryptWithPassphrase([]byte("c2hvcnQ="), "correct horse"); !errors.Is(err, security.ErrWrongPassphrase) {
+		t.Errorf("expected %v with a short content, got %v", security.ErrWrongPassphrase, err)
+	}
+}
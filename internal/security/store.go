@@ -0,0 +1,90 @@
+package security
+
+import (
+	"errors"
+	"os"
+	"sync"
+)
+
+// The names of the secret stores.
+const (
+	StoreKeyring = "keyring"
+	StoreFile    = "file"
+	StorePass    = "pass"
+	StoreEnv     = "env"
+)
+
+var (
+	ErrSecretNotFound = errors.New("secret not found")
+	ErrReadOnlyStore  = errors.New("the secret store is read-only")
+)
+
+// Stores the secret that encrypts the data.
+type SecretStore interface {
+	// Returns ErrSecretNotFound if there's no secret.
+	Get() (string, error)
+	Set(secret string) error
+	Delete() error
+}
+
+// Returns the names of the secret stores.
+func Stores() []string {
+	return []string{StoreKeyring, StoreFile, StorePass, StoreEnv}
+}
+
+// Reads the secret from an environment variable, it can't be modified.
+type EnvStore struct {
+	Variable string
+}
+
+func (s EnvStore) Get() (string, error) {
+	secret := os.Getenv(s.Variable)
+	if secret == "" {
+		return "", ErrSecretNotFound
+	}
+
+	return secret, nil
+}
+
+func (s EnvStore) Set(secret string) error {
+	return ErrReadOnlyStore
+}
+
+func (s EnvStore) Delete() error {
+	return ErrReadOnlyStore
+}
+
+// Keeps the secret in memory, it's lost when the program exits so it's
+// only meant for the tests.
+type MemoryStore struct {
+	mu     sync.Mutex
+	secret string
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (s *MemoryStore) Get() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.secret == "" {
+		return "", ErrSecretNotFound
+	}
+
+	return s.secret, nil
+}
+
+func (s *MemoryStore) Set(secret string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.secret = secret
+
+	return nil
+}
+
+func (s *MemoryStore) Delete() error {
+	return s.Set("")
+}
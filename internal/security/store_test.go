@@ -0,0 +1,94 @@
+package security_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/luisnquin/nao/v3/internal/security"
+)
+
+func TestSecretStores(t *testing.T) {
+	prompts := 0
+
+	file := &security.FileStore{
+		Path: filepath.Join(t.TempDir(), "keystore"),
+		Passphrase: func() (string, error) {
+			prompts++
+
+			return "correct horse", nil
+		},
+	}
+
+	for name, store := range map[string]security.SecretStore{
+		"memory":           security.NewMemoryStore(),
+		security.StoreFile: file,
+	} {
+		if _, err := store.Get(); !errors.Is(err, security.ErrSecretNotFound) {
+			t.Errorf("%s: expected %v, got %v", name, security.ErrSecretNotFound, err)
+		}
+
+		if err := store.Set("s3cr3t"); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if secret, err := store.Get(); err != nil || secret != "s3cr3t" {
+			t.Errorf("%s: expected the stored secret, got %q and %v", name, secret, err)
+		}
+
+		if err := store.Delete(); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if _, err := store.Get(); !errors.Is(err, security.ErrSecretNotFound) {
+			t.Errorf("%s: expected %v after deleting it, got %v", name, security.ErrSecretNotFound, err)
+		}
+	}
+
+	if prompts != 1 {
+		t.Errorf("expected the passphrase to be prompted once, got %d", prompts)
+	}
+
+	typed := []string{"correct horse", "corect horse", "correct horse", "correct horse"}
+
+	file = &security.FileStore{
+		Path: filepath.Join(t.TempDir(), "keystore"),
+		NewPassphrase: func() (string, error) {
+			return security.NewPassphrase(func(string) (string, error) {
+				passphrase := typed[0]
+				typed = typed[1:]
+
+				return passphrase, nil
+			}, "New passphrase: ")
+		},
+	}
+
+	if err := file.Set("s3cr3t"); !errors.Is(err, security.ErrPassphraseMismatch) {
+		t.Errorf("expected %v with a mistyped passphrase, got %v", security.ErrPassphraseMismatch, err)
+	}
+
+	if _, err := os.Stat(file.Path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("the keystore shouldn't be created with a mistyped passphrase, got %v", err)
+	}
+
+	if err := file.Set("s3cr3t"); err != nil {
+		t.Errorf("unexpected error with a confirmed passphrase: %v", err)
+	}
+
+	env := security.EnvStore{Variable: "NAO_TEST_SECRET"}
+
+	if _, err := env.Get(); !errors.Is(err, security.ErrSecretNotFound) {
+		t.Errorf("expected %v, got %v", security.ErrSecretNotFound, err)
+	}
+
+	t.Setenv("NAO_TEST_SECRET", "s3cr3t")
+
+	if secret, err := env.Get(); err != nil || secret != "s3cr3t" {
+		t.Errorf("expected the secret of the variable, got %q and %v", secret, err)
+	}
+
+	if err := env.Set("other"); !errors.Is(err, security.ErrReadOnlyStore) {
+		t.Errorf("expected %v, got %v", security.ErrReadOnlyStore, err)
+	}
+}
@@ -0,0 +1,200 @@
+package share
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"github.com/luisnquin/nao/v3/internal"
+)
+
+const (
+	identityFile   = "identity.txt"
+	recipientsFile = "recipients.txt"
+)
+
+var (
+	ErrNoIdentity         = errors.New("there's no key pair, generate one with 'nao keys generate'")
+	ErrIdentityExists     = errors.New("there's already a key pair")
+	ErrRecipientNotFound  = errors.New("recipient not found")
+	ErrRecipientExists    = errors.New("recipient already exists")
+	ErrInvalidContactName = errors.New("the names of the recipients can't contain spaces")
+)
+
+// A public key saved with a name.
+type Contact struct {
+	Name      string `json:"name"`
+	PublicKey string `json:"publicKey"`
+}
+
+// The key pair of the user and the public keys of other users, stored
+// in the directory as files compatible with the age CLI.
+type Keys struct {
+	Dir string
+}
+
+// Returns the key pair of the user.
+func (k Keys) Identity() (*age.X25519Identity, error) {
+	content, err := os.ReadFile(path.Join(k.Dir, identityFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoIdentity
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		return age.ParseX25519Identity(line)
+	}
+
+	return nil, ErrNoIdentity
+}
+
+// Generates and stores a new key pair, the existing one is never replaced.
+func (k Keys) Generate() (*age.X25519Identity, error) {
+	if _, err := k.Identity(); !errors.Is(err, ErrNoIdentity) {
+		if err == nil {
+			err = ErrIdentityExists
+		}
+
+		return nil, err
+	}
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(k.Dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	content := fmt.Sprintf("# created: %s\n# public key: %s\n%s\n",
+		time.Now().Format(time.RFC3339), identity.Recipient(), identity)
+
+	f, err := os.OpenFile(path.Join(k.Dir, identityFile), os.O_WRONLY|os.O_CREATE|os.O_EXCL, internal.PermReadWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+
+		return nil, err
+	}
+
+	return identity, f.Close()
+}
+
+// Returns the saved public keys, in the order they were added.
+func (k Keys) Contacts() ([]Contact, error) {
+	f, err := os.Open(path.Join(k.Dir, recipientsFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	var contacts []Contact
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		contacts = append(contacts, Contact{Name: fields[0], PublicKey: fields[1]})
+	}
+
+	return contacts, scanner.Err()
+}
+
+// Saves the public key with a name, to be used instead of the key.
+func (k Keys) AddContact(name, publicKey string) error {
+	if name == "" || strings.ContainsAny(name, " \t\n#") {
+		return ErrInvalidContactName
+	}
+
+	if _, err := age.ParseX25519Recipient(publicKey); err != nil {
+		return err
+	}
+
+	contacts, err := k.Contacts()
+	if err != nil {
+		return err
+	}
+
+	for _, c := range contacts {
+		if c.Name == name {
+			return fmt.Errorf("%w: %s", ErrRecipientExists, name)
+		}
+	}
+
+	return k.writeContacts(append(contacts, Contact{Name: name, PublicKey: publicKey}))
+}
+
+func (k Keys) RemoveContact(name string) error {
+	contacts, err := k.Contacts()
+	if err != nil {
+		return err
+	}
+
+	for i, c := range contacts {
+		if c.Name == name {
+			return k.writeContacts(append(contacts[:i], contacts[i+1:]...))
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrRecipientNotFound, name)
+}
+
+// Parses a public key or returns the one saved with the name.
+func (k Keys) Recipient(ref string) (*age.X25519Recipient, error) {
+	if strings.HasPrefix(ref, "age1") {
+		return age.ParseX25519Recipient(ref)
+	}
+
+	contacts, err := k.Contacts()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range contacts {
+		if c.Name == ref {
+			return age.ParseX25519Recipient(c.PublicKey)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrRecipientNotFound, ref)
+}
+
+func (k Keys) writeContacts(contacts []Contact) error {
+	if err := os.MkdirAll(k.Dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+
+	for _, c := range contacts {
+		fmt.Fprintf(&b, "%s %s\n", c.Name, c.PublicKey)
+	}
+
+	return os.WriteFile(path.Join(k.Dir, recipientsFile), []byte(b.String()), internal.PermReadWrite)
+}
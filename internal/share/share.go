@@ -0,0 +1,94 @@
+package share
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/goccy/go-json"
+	"github.com/luisnquin/nao/v3/internal/models"
+)
+
+// The version of the bundles written by Encrypt.
+const Version = 1
+
+var ErrUnsupportedVersion = errors.New("unsupported bundle version")
+
+// The content of a shared file, encrypted in the age format.
+type Bundle struct {
+	Version  int       `json:"version"`
+	SharedAt time.Time `json:"sharedAt"`
+	// The public key of the sender, if there's one.
+	From string      `json:"from,omitempty"`
+	Note models.Note `json:"note"`
+}
+
+// Returns a bundle of the note without the data that only makes sense
+// to the sender, like the picks and the attachments.
+func NewBundle(nt models.Note, from string) Bundle {
+	nt.Picks = 0
+	nt.LastAccessedAt = time.Time{}
+	nt.Attachments = nil
+	nt.Pinned, nt.Archived, nt.Favorite = false, false, false
+
+	return Bundle{Version: Version, SharedAt: time.Now(), From: from, Note: nt}
+}
+
+// Writes the bundle encrypted for the recipients, ASCII armored so it can
+// be pasted anywhere. The file can also be decrypted with the age CLI.
+func Encrypt(w io.Writer, bundle Bundle, recipients ...age.Recipient) error {
+	content, err := json.Marshal(bundle)
+	if err != nil {
+		return err
+	}
+
+	armored := armor.NewWriter(w)
+
+	encrypted, err := age.Encrypt(armored, recipients...)
+	if err != nil {
+		return err
+	}
+
+	if _, err := encrypted.Write(content); err != nil {
+		return err
+	}
+
+	if err := encrypted.Close(); err != nil {
+		return err
+	}
+
+	return armored.Close()
+}
+
+// Reads a bundle encrypted for one of the identities, armored or not.
+func Decrypt(r io.Reader, identities ...age.Identity) (Bundle, error) {
+	buffered := bufio.NewReader(r)
+
+	var src io.Reader = buffered
+
+	if start, _ := buffered.Peek(len(armor.Header)); bytes.Equal(start, []byte(armor.Header)) {
+		src = armor.NewReader(buffered)
+	}
+
+	decrypted, err := age.Decrypt(src, identities...)
+	if err != nil {
+		return Bundle{}, err
+	}
+
+	var bundle Bundle
+
+	if err := json.NewDecoder(decrypted).Decode(&bundle); err != nil {
+		return Bundle{}, fmt.Errorf("invalid bundle: %w", err)
+	}
+
+	if bundle.Version != Version {
+		return Bundle{}, fmt.Errorf("%w %d, expected %d", ErrUnsupportedVersion, bundle.Version, Version)
+	}
+
+	return bundle, nil
+}
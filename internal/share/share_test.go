@@ -0,0 +1,102 @@
+package share_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/luisnquin/nao/v3/internal/models"
+	"github.com/luisnquin/nao/v3/internal/share"
+)
+
+func TestBundle(t *testing.T) {
+	alice, _ := age.GenerateX25519Identity()
+	bob, _ := age.GenerateX25519Identity()
+
+	nt := models.Note{Tag: "deploy", Content: "make release", Version: 3, Picks: 8, Pinned: true, TimeSpent: time.Minute}
+
+	var b bytes.Buffer
+
+	if err := share.Encrypt(&b, share.NewBundle(nt, alice.Recipient().String()), bob.Recipient()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(b.String(), "-----BEGIN AGE ENCRYPTED FILE-----") {
+		t.Errorf("expected an armored file, got %q", b.String())
+	}
+
+	if _, err := share.Decrypt(bytes.NewReader(b.Bytes()), alice); err == nil {
+		t.Error("expected an error with an identity that isn't a recipient")
+	}
+
+	bundle, err := share.Decrypt(&b, bob)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if bundle.From != alice.Recipient().String() {
+		t.Errorf("expected the sender %s, got %s", alice.Recipient(), bundle.From)
+	}
+
+	received := bundle.Note
+	if received.Tag != "deploy" || received.Content != "make release" || received.Version != 3 || received.TimeSpent != time.Minute {
+		t.Errorf("the note wasn't received as it was shared: %+v", received)
+	}
+
+	if received.Picks != 0 || received.Pinned {
+		t.Errorf("expected the picks and the states of the sender to be removed: %+v", received)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	keys := share.Keys{Dir: t.TempDir()}
+
+	if _, err := keys.Identity(); !errors.Is(err, share.ErrNoIdentity) {
+		t.Errorf("expected %v, got %v", share.ErrNoIdentity, err)
+	}
+
+	identity, err := keys.Generate()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := keys.Generate(); !errors.Is(err, share.ErrIdentityExists) {
+		t.Errorf("expected %v, got %v", share.ErrIdentityExists, err)
+	}
+
+	stored, err := keys.Identity()
+	if err != nil || stored.String() != identity.String() {
+		t.Fatalf("expected the generated identity, got %v, %v", stored, err)
+	}
+
+	publicKey := identity.Recipient().String()
+
+	if err := keys.AddContact("bob", publicKey); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := keys.AddContact("bob", publicKey); !errors.Is(err, share.ErrRecipientExists) {
+		t.Errorf("expected %v, got %v", share.ErrRecipientExists, err)
+	}
+
+	if err := keys.AddContact("eve", "age1nope"); err == nil {
+		t.Error("expected an error with an invalid public key")
+	}
+
+	for _, ref := range []string{"bob", publicKey} {
+		if r, err := keys.Recipient(ref); err != nil || r.String() != publicKey {
+			t.Errorf("expected %s with %s, got %v, %v", publicKey, ref, r, err)
+		}
+	}
+
+	if err := keys.RemoveContact("bob"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := keys.Recipient("bob"); !errors.Is(err, share.ErrRecipientNotFound) {
+		t.Errorf("expected %v, got %v", share.ErrRecipientNotFound, err)
+	}
+}
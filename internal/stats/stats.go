@@ -0,0 +1,197 @@
+// Package stats summarizes the notes.
+package stats
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/luisnquin/nao/v3/internal/models"
+)
+
+type (
+	Stats struct {
+		Notes int `json:"notes"`
+		// In bytes.
+		Size int `json:"size"`
+		// In seconds.
+		TimeSpent int64 `json:"timeSpent"`
+		// The sum of the versions of every note.
+		Versions int `json:"versions"`
+		// From the oldest to the current one.
+		Weeks      []Week  `json:"weeks"`
+		Largest    []Entry `json:"largest"`
+		MostEdited []Entry `json:"mostEdited"`
+		Groups     []Group `json:"groups"`
+	}
+
+	// The number of notes created and last edited in a week.
+	Week struct {
+		// The monday of the week, like 2006-01-02.
+		Start   string `json:"start"`
+		Created int    `json:"created"`
+		Edited  int    `json:"edited"`
+	}
+
+	Entry struct {
+		Key       string `json:"key"`
+		Tag       string `json:"tag"`
+		Size      int    `json:"size"`
+		Version   int    `json:"version"`
+		TimeSpent int64  `json:"timeSpent"`
+	}
+
+	// The notes whose tags share the same prefix, like 'work' for
+	// 'work-meetings' and 'work/todo'.
+	Group struct {
+		Name      string `json:"name"`
+		Notes     int    `json:"notes"`
+		Size      int    `json:"size"`
+		TimeSpent int64  `json:"timeSpent"`
+	}
+)
+
+// Summarizes the notes, the weeks go back from the current one and the
+// rankings have at most top entries.
+func Compute(notes []models.Note, now time.Time, weeks, top int) Stats {
+	s := Stats{Notes: len(notes), Weeks: make([]Week, weeks)}
+
+	current := startOfWeek(now)
+
+	for i := range s.Weeks {
+		s.Weeks[i].Start = current.AddDate(0, 0, -7*(weeks-1-i)).Format("2006-01-02")
+	}
+
+	groups := make(map[string]*Group)
+	entries := make([]Entry, len(notes))
+
+	for i, n := range notes {
+		size, timeSpent := n.Size(), int64(n.TimeSpent/time.Second)
+
+		s.Size += size
+		s.TimeSpent += timeSpent
+		s.Versions += n.Version
+
+		if i := weekIndex(current, n.CreatedAt, weeks); i != -1 {
+			s.Weeks[i].Created++
+		}
+
+		if i := weekIndex(current, n.LastUpdate, weeks); i != -1 {
+			s.Weeks[i].Edited++
+		}
+
+		name := GroupName(n.Tag)
+
+		g, ok := groups[name]
+		if !ok {
+			g = &Group{Name: name}
+			groups[name] = g
+		}
+
+		g.Notes++
+		g.Size += size
+		g.TimeSpent += timeSpent
+
+		entries[i] = Entry{Key: n.Key, Tag: n.Tag, Size: size, Version: n.Version, TimeSpent: timeSpent}
+	}
+
+	s.Largest = rank(entries, top, func(a, b Entry) bool { return a.Size > b.Size })
+	s.MostEdited = rank(entries, top, func(a, b Entry) bool { return a.Version > b.Version })
+
+	s.Groups = make([]Group, 0, len(groups))
+
+	for _, g := range groups {
+		s.Groups = append(s.Groups, *g)
+	}
+
+	sort.Slice(s.Groups, func(i, j int) bool {
+		if s.Groups[i].Notes != s.Groups[j].Notes {
+			return s.Groups[i].Notes > s.Groups[j].Notes
+		}
+
+		return s.Groups[i].Name < s.Groups[j].Name
+	})
+
+	return s
+}
+
+// Returns the prefix of the tag before the first separator.
+func GroupName(tag string) string {
+	if i := strings.IndexAny(tag, "-_/.:"); i > 0 {
+		return tag[:i]
+	}
+
+	return tag
+}
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// Draws the values as a line of bars relative to the greatest one, only
+// the zeros are drawn with the lowest bar.
+func Sparkline(values []int) string {
+	greatest := 0
+
+	for _, v := range values {
+		if v > greatest {
+			greatest = v
+		}
+	}
+
+	var b strings.Builder
+
+	for _, v := range values {
+		if v <= 0 {
+			b.WriteRune(sparks[0])
+
+			continue
+		}
+
+		b.WriteRune(sparks[(v*(len(sparks)-1)+greatest-1)/greatest])
+	}
+
+	return b.String()
+}
+
+func startOfWeek(t time.Time) time.Time {
+	t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+
+	// Monday as the first day of the week
+	return t.AddDate(0, 0, -(int(t.Weekday())+6)%7)
+}
+
+func weekIndex(current, t time.Time, weeks int) int {
+	if t.IsZero() {
+		return -1
+	}
+
+	diff := current.Sub(startOfWeek(t.In(current.Location())))
+	ago := int((diff + 12*time.Hour) / (7 * 24 * time.Hour)) // Tolerates the DST changes
+
+	if ago < 0 || ago >= weeks {
+		return -1
+	}
+
+	return weeks - 1 - ago
+}
+
+func rank(entries []Entry, top int, less func(a, b Entry) bool) []Entry {
+	ranked := append([]Entry{}, entries...)
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if less(ranked[i], ranked[j]) {
+			return true
+		}
+
+		if less(ranked[j], ranked[i]) {
+			return false
+		}
+
+		return ranked[i].Key < ranked[j].Key
+	})
+
+	if len(ranked) > top {
+		ranked = ranked[:top]
+	}
+
+	return ranked
+}
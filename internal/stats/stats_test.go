@@ -0,0 +1,51 @@
+package stats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luisnquin/nao/v3/internal/models"
+	"github.com/luisnquin/nao/v3/internal/stats"
+)
+
+func TestCompute(t *testing.T) {
+	now := time.Date(2023, 5, 24, 12, 0, 0, 0, time.UTC) // Wednesday
+
+	notes := []models.Note{
+		{Key: "a", Tag: "work-todo", Version: 7, TimeSpent: time.Minute, CreatedAt: now.AddDate(0, 0, -20), LastUpdate: now},
+		{Key: "b", Tag: "work/ideas", Version: 2, TimeSpent: time.Hour, CreatedAt: now.AddDate(0, 0, -1), LastUpdate: now.AddDate(0, 0, -1)},
+		{Key: "c", Tag: "groceries", Content: "- milk\n- green tea", Version: 1, CreatedAt: now.AddDate(-1, 0, 0), LastUpdate: now.AddDate(0, 0, -9)},
+	}
+
+	s := stats.Compute(notes, now, 4, 2)
+
+	if s.Notes != 3 || s.Versions != 10 || s.TimeSpent != 3660 {
+		t.Errorf("unexpected totals: %+v", s)
+	}
+
+	if len(s.Weeks) != 4 || s.Weeks[3].Start != "2023-05-22" || s.Weeks[0].Start != "2023-05-01" {
+		t.Errorf("unexpected weeks: %+v", s.Weeks)
+	}
+
+	if s.Weeks[3].Created != 1 || s.Weeks[3].Edited != 2 || s.Weeks[2].Edited != 1 || s.Weeks[0].Created != 1 {
+		t.Errorf("unexpected activity: %+v", s.Weeks)
+	}
+
+	if len(s.Largest) != 2 || s.Largest[0].Key != "c" {
+		t.Errorf("expected 'c' as the largest note, got %+v", s.Largest)
+	}
+
+	if len(s.MostEdited) != 2 || s.MostEdited[0].Key != "a" || s.MostEdited[1].Key != "b" {
+		t.Errorf("unexpected most edited notes: %+v", s.MostEdited)
+	}
+
+	if len(s.Groups) != 2 || s.Groups[0].Name != "work" || s.Groups[0].Notes != 2 {
+		t.Errorf("unexpected groups: %+v", s.Groups)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if line := stats.Sparkline([]int{0, 1, 4, 8}); line != "▁▂▅█" {
+		t.Errorf("unexpected sparkline %q", line)
+	}
+}
@@ -0,0 +1,128 @@
+// Package tasks extracts the Markdown checklist items of the notes.
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/luisnquin/nao/v3/internal/models"
+)
+
+var (
+	ErrInvalidID = errors.New("invalid task id, expected <id>:<line> or <tag>:<line>")
+	ErrNotATask  = errors.New("the line is not a task")
+)
+
+// Like '- [ ] buy milk', '* [x] done' or '1. [ ] first'.
+var rxTask = regexp.MustCompile(`^(\s*(?:[-*+]|\d+[.)])\s+\[)([ xX])(\]\s+)(.*)$`)
+
+type Task struct {
+	Key  string `json:"key"`
+	Tag  string `json:"tag"`
+	Line int    `json:"line"`
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
+
+// Returns the identifier of the task, the tag of the note and the line.
+func (t Task) ID() string {
+	return t.Tag + ":" + strconv.Itoa(t.Line)
+}
+
+// Returns the tasks of the content, the code blocks are skipped.
+func Parse(content string) []Task {
+	var (
+		tasks   []Task
+		inFence bool
+	)
+
+	for i, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+
+			continue
+		}
+
+		if inFence {
+			continue
+		}
+
+		if m := rxTask.FindStringSubmatch(line); m != nil {
+			tasks = append(tasks, Task{
+				Line: i + 1,
+				Text: strings.TrimSpace(m[4]),
+				Done: m[2] != " ",
+			})
+		}
+	}
+
+	return tasks
+}
+
+// Returns the tasks of every note sorted by tag and line. The secrets of the
+// text are masked, so the sensitive and locked notes don't have tasks.
+func Collect(notes []models.Note) []Task {
+	var tasks []Task
+
+	for _, n := range notes {
+		for _, t := range Parse(n.MaskedContent()) {
+			t.Key, t.Tag = n.Key, n.Tag
+			tasks = append(tasks, t)
+		}
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].Tag != tasks[j].Tag {
+			return tasks[i].Tag < tasks[j].Tag
+		}
+
+		return tasks[i].Line < tasks[j].Line
+	})
+
+	return tasks
+}
+
+// Splits an identifier like 'todo:12' into the reference of the note and the line.
+func ParseID(id string) (string, int, error) {
+	i := strings.LastIndex(id, ":")
+	if i <= 0 {
+		return "", 0, ErrInvalidID
+	}
+
+	line, err := strconv.Atoi(id[i+1:])
+	if err != nil || line < 1 {
+		return "", 0, ErrInvalidID
+	}
+
+	return id[:i], line, nil
+}
+
+// Checks or unchecks the task of the line, returning the new content and
+// the task as it's now.
+func Toggle(content string, line int) (string, Task, error) {
+	lines := strings.Split(content, "\n")
+
+	for _, task := range Parse(content) {
+		if task.Line != line {
+			continue
+		}
+
+		m := rxTask.FindStringSubmatch(lines[line-1])
+
+		mark := "x"
+		if task.Done {
+			mark = " "
+		}
+
+		lines[line-1] = m[1] + mark + m[3] + m[4]
+		task.Done = !task.Done
+
+		return strings.Join(lines, "\n"), task, nil
+	}
+
+	return content, Task{}, fmt.Errorf("%w: %d", ErrNotATask, line)
+}
@@ -0,0 +1,86 @@
+package tasks_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/luisnquin/nao/v3/internal/models"
+	"github.com/luisnquin/nao/v3/internal/secrets"
+	"github.com/luisnquin/nao/v3/internal/tasks"
+)
+
+const content = "# Todo\n- [ ] buy milk\n  * [x] call mom\n1. [ ] first\n```\n- [ ] not a task\n```\n- [] nope"
+
+func TestParse(t *testing.T) {
+	expected := []tasks.Task{
+		{Line: 2, Text: "buy milk"},
+		{Line: 3, Text: "call mom", Done: true},
+		{Line: 4, Text: "first"},
+	}
+
+	result := tasks.Parse(content)
+	if len(result) != len(expected) {
+		t.Fatalf("expected %d tasks, got %v", len(expected), result)
+	}
+
+	for i, task := range result {
+		if task != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], task)
+		}
+	}
+}
+
+func TestToggle(t *testing.T) {
+	toggled, task, err := tasks.Toggle(content, 2)
+	if err != nil || !task.Done || tasks.Parse(toggled)[0] != task {
+		t.Fatalf("unexpected result %+v, %v", task, err)
+	}
+
+	toggled, task, err = tasks.Toggle(toggled, 2)
+	if err != nil || task.Done || toggled != content {
+		t.Errorf("expected the original content, got %q and %v", toggled, err)
+	}
+
+	for _, line := range []int{0, 1, 6, 100} {
+		if _, _, err := tasks.Toggle(content, line); !errors.Is(err, tasks.ErrNotATask) {
+			t.Errorf("expected an error for the line %d, got %v", line, err)
+		}
+	}
+}
+
+func TestCollect(t *testing.T) {
+	notes := []models.Note{
+		{Key: "b", Tag: "work", Content: "- [ ] rotate [[secret:hunter2]]"},
+		{Key: "a", Tag: "keys", Content: "- [ ] hunter2", Sensitive: true},
+		{Key: "c", Tag: "home", Content: "- [x] buy milk"},
+	}
+
+	expected := []tasks.Task{
+		{Key: "c", Tag: "home", Line: 1, Text: "buy milk", Done: true},
+		{Key: "b", Tag: "work", Line: 1, Text: "rotate " + secrets.Mask},
+	}
+
+	result := tasks.Collect(notes)
+	if len(result) != len(expected) {
+		t.Fatalf("expected %d tasks, got %v", len(expected), result)
+	}
+
+	for i, task := range result {
+		if task != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], task)
+		}
+	}
+}
+
+func TestParseID(t *testing.T) {
+	ref, line, err := tasks.ParseID("work:todo:12")
+	if err != nil || ref != "work:todo" || line != 12 {
+		t.Errorf("unexpected result %q, %d and %v", ref, line, err)
+	}
+
+	for _, id := range []string{"todo", ":12", "todo:0", "todo:x"} {
+		if _, _, err := tasks.ParseID(id); !errors.Is(err, tasks.ErrInvalidID) {
+			t.Errorf("expected an error for %q, got %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,519 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/mattn/go-runewidth"
+)
+
+// An element that can be listed by the browser.
+type Item struct {
+	Key     string
+	Tag     string
+	Cells   []string
+	Content string
+}
+
+type BrowserColors struct {
+	Header  color.PrinterFace
+	Accent  color.PrinterFace
+	Columns []color.PrinterFace
+}
+
+// Browser is a full-screen list of items with a preview pane, the
+// actions are provided by the caller and reload the items after
+// being executed.
+type Browser struct {
+	Header []string
+	Colors BrowserColors
+
+	Load   func() ([]Item, error)
+	Edit   func(key string) error
+	Rename func(key, tag string) error
+	Delete func(key string) error
+	Copy   func(key string) error
+
+	term    *Terminal
+	items   []Item
+	visible []int
+	cursor  int
+	offset  int
+	filter  string
+	input   string
+	mode    browserMode
+	status  string
+}
+
+type browserMode int
+
+const (
+	modeNormal browserMode = iota
+	modeFilter
+	modeRename
+	modeDelete
+)
+
+var errQuit = errors.New("quit")
+
+const browserHelp = "↑/↓ move · / filter · enter edit · r rename · d delete · y copy · q quit"
+
+func (b *Browser) Run() error {
+	if err := b.reload(); err != nil {
+		return err
+	}
+
+	t, err := Open()
+	if err != nil {
+		return err
+	}
+
+	b.term = t
+
+	defer t.Close()
+
+	for {
+		if err := t.Draw(b.render()); err != nil {
+			return err
+		}
+
+		key, err := t.ReadKey()
+		if err != nil {
+			return err
+		}
+
+		if err := b.handle(key); err != nil {
+			if errors.Is(err, errQuit) {
+				return nil
+			}
+
+			b.status = "error: " + err.Error()
+		}
+	}
+}
+
+func (b *Browser) reload() error {
+	items, err := b.Load()
+	if err != nil {
+		return err
+	}
+
+	b.items = items
+	b.applyFilter()
+
+	return nil
+}
+
+func (b *Browser) applyFilter() {
+	query := strings.ToLower(b.filter)
+
+	b.visible = b.visible[:0]
+
+	for i, item := range b.items {
+		if query == "" || strings.Contains(strings.ToLower(item.Tag), query) ||
+			strings.HasPrefix(item.Key, query) {
+			b.visible = append(b.visible, i)
+		}
+	}
+
+	if b.cursor >= len(b.visible) {
+		b.cursor = len(b.visible) - 1
+	}
+
+	if b.cursor < 0 {
+		b.cursor = 0
+	}
+}
+
+func (b *Browser) selected() (Item, bool) {
+	if len(b.visible) == 0 {
+		return Item{}, false
+	}
+
+	return b.items[b.visible[b.cursor]], true
+}
+
+func (b *Browser) handle(key Key) error {
+	switch b.mode {
+	case modeFilter:
+		return b.handleFilter(key)
+	case modeRename:
+		return b.handleRename(key)
+	case modeDelete:
+		return b.handleDelete(key)
+	}
+
+	b.status = ""
+
+	switch {
+	case key.Code == KeyCtrlC, key.Code == KeyEscape, key.Code == KeyRune && key.Rune == 'q':
+		return errQuit
+
+	case key.Code == KeyUp, key.Code == KeyCtrlP, key.Code == KeyRune && key.Rune == 'k':
+		b.move(-1)
+
+	case key.Code == KeyDown, key.Code == KeyCtrlN, key.Code == KeyRune && key.Rune == 'j':
+		b.move(1)
+
+	case key.Code == KeyPageUp:
+		b.move(-b.pageSize())
+
+	case key.Code == KeyPageDown:
+		b.move(b.pageSize())
+
+	case key.Code == KeyHome, key.Code == KeyRune && key.Rune == 'g':
+		b.move(-len(b.visible))
+
+	case key.Code == KeyEnd, key.Code == KeyRune && key.Rune == 'G':
+		b.move(len(b.visible))
+
+	case key.Code == KeyRune && key.Rune == '/':
+		b.mode = modeFilter
+
+	case key.Code == KeyEnter, key.Code == KeyRune && key.Rune == 'e':
+		return b.edit()
+
+	case key.Code == KeyRune && key.Rune == 'r':
+		if item, ok := b.selected(); ok {
+			b.mode, b.input = modeRename, item.Tag
+		}
+
+	case key.Code == KeyRune && key.Rune == 'd':
+		if _, ok := b.selected(); ok {
+			b.mode = modeDelete
+		}
+
+	case key.Code == KeyRune && key.Rune == 'y':
+		if item, ok := b.selected(); ok {
+			if err := b.Copy(item.Key); err != nil {
+				return err
+			}
+
+			b.status = fmt.Sprintf("content of '%s' copied", item.Tag)
+		}
+	}
+
+	return nil
+}
+
+func (b *Browser) handleFilter(key Key) error {
+	switch key.Code {
+	case KeyEnter, KeyUp, KeyDown:
+		b.mode = modeNormal
+
+		if key.Code != KeyEnter {
+			return b.handle(key)
+		}
+	case KeyEscape, KeyCtrlC:
+		b.mode, b.filter = modeNormal, ""
+	case KeyBackspace:
+		b.filter = dropLastRune(b.filter)
+	case KeyCtrlU:
+		b.filter = ""
+	case KeyRune:
+		b.filter += string(key.Rune)
+	}
+
+	b.applyFilter()
+
+	return nil
+}
+
+func (b *Browser) handleRename(key Key) error {
+	switch key.Code {
+	case KeyEnter:
+		b.mode = modeNormal
+
+		item, ok := b.selected()
+		if !ok || b.input == item.Tag {
+			return nil
+		}
+
+		if err := b.Rename(item.Key, b.input); err != nil {
+			return err
+		}
+
+		b.status = fmt.Sprintf("'%s' renamed to '%s'", item.Tag, b.input)
+
+		return b.reload()
+	case KeyEscape, KeyCtrlC:
+		b.mode = modeNormal
+	case KeyBackspace:
+		b.input = dropLastRune(b.input)
+	case KeyCtrlU:
+		b.input = ""
+	case KeyRune:
+		b.input += string(key.Rune)
+	}
+
+	return nil
+}
+
+func (b *Browser) handleDelete(key Key) error {
+	b.mode = modeNormal
+
+	if key.Code != KeyRune || (key.Rune != 'y' && key.Rune != 'Y') {
+		return nil
+	}
+
+	item, ok := b.selected()
+	if !ok {
+		return nil
+	}
+
+	if err := b.Delete(item.Key); err != nil {
+		return err
+	}
+
+	b.status = fmt.Sprintf("'%s' deleted", item.Tag)
+
+	return b.reload()
+}
+
+func (b *Browser) edit() error {
+	item, ok := b.selected()
+	if !ok {
+		return nil
+	}
+
+	if err := b.term.Suspend(); err != nil {
+		return err
+	}
+
+	editErr := b.Edit(item.Key)
+
+	if err := b.term.Resume(); err != nil {
+		return err
+	}
+
+	if editErr != nil {
+		return editErr
+	}
+
+	return b.reload()
+}
+
+func (b *Browser) move(delta int) {
+	b.cursor += delta
+
+	if b.cursor >= len(b.visible) {
+		b.cursor = len(b.visible) - 1
+	}
+
+	if b.cursor < 0 {
+		b.cursor = 0
+	}
+}
+
+func (b *Browser) pageSize() int {
+	_, height := b.term.Size()
+
+	if height > 6 {
+		return height / 2
+	}
+
+	return 1
+}
+
+func (b *Browser) render() string {
+	width, height := b.term.Size()
+
+	bodyHeight := height - 2
+	if bodyHeight < 2 {
+		bodyHeight = 2
+	}
+
+	var left, right []string
+
+	if width >= 100 {
+		listWidth := width * 55 / 100
+
+		left = b.renderList(listWidth, bodyHeight)
+		right = b.renderPreview(width-listWidth-3, bodyHeight)
+
+		for i := range left {
+			left[i] += " │ " + right[i]
+		}
+	} else {
+		listHeight := bodyHeight / 2
+
+		left = b.renderList(width, listHeight)
+		left = append(left, strings.Repeat("─", width))
+		left = append(left, b.renderPreview(width, bodyHeight-listHeight-1)...)
+	}
+
+	var frame strings.Builder
+
+	frame.WriteString(b.renderTitle(width))
+	frame.WriteString("\r\n")
+
+	for _, line := range left {
+		frame.WriteString(line)
+		frame.WriteString("\r\n")
+	}
+
+	frame.WriteString(b.renderStatus(width))
+
+	return frame.String()
+}
+
+func (b *Browser) renderTitle(width int) string {
+	title := fmt.Sprintf(" nao · %d/%d", len(b.visible), len(b.items))
+
+	if b.filter != "" || b.mode == modeFilter {
+		title += " · /" + b.filter
+	}
+
+	return b.Colors.Accent.Sprint(fit(title, width))
+}
+
+func (b *Browser) renderStatus(width int) string {
+	switch b.mode {
+	case modeFilter:
+		return fit("filter: "+b.filter+"▏", width)
+	case modeRename:
+		return fit("new tag: "+b.input+"▏", width)
+	case modeDelete:
+		item, _ := b.selected()
+
+		return b.Colors.Accent.Sprint(fit(fmt.Sprintf("delete '%s'? [y/N]", item.Tag), width))
+	}
+
+	if b.status != "" {
+		return fit(b.status, width)
+	}
+
+	return fit(browserHelp, width)
+}
+
+func (b *Browser) columnWidths() []int {
+	widths := make([]int, len(b.Header))
+
+	for i, column := range b.Header {
+		widths[i] = runewidth.StringWidth(column)
+	}
+
+	for _, item := range b.items {
+		for i, cell := range item.Cells {
+			if i < len(widths) && runewidth.StringWidth(cell) > widths[i] {
+				widths[i] = runewidth.StringWidth(cell)
+			}
+		}
+	}
+
+	return widths
+}
+
+// Joins the cells with a gap, truncating the ones that doesn't fit in
+// the width. The colorizer is applied to each visible cell.
+func joinCells(cells []string, widths []int, width int, colorize func(i int, s string) string) string {
+	var (
+		b    strings.Builder
+		used int
+	)
+
+	for i, cell := range cells {
+		if i >= len(widths) || used >= width {
+			break
+		}
+
+		cellWidth := widths[i]
+		if used+cellWidth > width {
+			cellWidth = width - used
+		}
+
+		b.WriteString(colorize(i, fit(cell, cellWidth)))
+		used += cellWidth
+
+		if used+2 <= width {
+			b.WriteString("  ")
+			used += 2
+		}
+	}
+
+	if used < width {
+		b.WriteString(strings.Repeat(" ", width-used))
+	}
+
+	return b.String()
+}
+
+func (b *Browser) renderList(width, height int) []string {
+	lines := make([]string, 0, height)
+	widths := b.columnWidths()
+
+	lines = append(lines, "  "+joinCells(b.Header, widths, width-2, func(_ int, s string) string {
+		return b.Colors.Header.Sprint(s)
+	}))
+
+	rows := height - 1
+
+	if b.cursor < b.offset {
+		b.offset = b.cursor
+	}
+
+	if b.cursor >= b.offset+rows {
+		b.offset = b.cursor - rows + 1
+	}
+
+	for i := b.offset; i < len(b.visible) && len(lines) < height; i++ {
+		item := b.items[b.visible[i]]
+
+		if i == b.cursor {
+			plain := joinCells(item.Cells, widths, width-2, func(_ int, s string) string { return s })
+			lines = append(lines, b.Colors.Accent.Sprint("▌ ")+"\x1b[7m"+plain+"\x1b[0m")
+
+			continue
+		}
+
+		lines = append(lines, "  "+joinCells(item.Cells, widths, width-2, func(j int, s string) string {
+			if j < len(b.Colors.Columns) {
+				return b.Colors.Columns[j].Sprint(s)
+			}
+
+			return s
+		}))
+	}
+
+	for len(lines) < height {
+		lines = append(lines, strings.Repeat(" ", width))
+	}
+
+	return lines
+}
+
+func (b *Browser) renderPreview(width, height int) []string {
+	lines := make([]string, 0, height)
+
+	item, ok := b.selected()
+	if !ok {
+		lines = append(lines, fit("no notes", width))
+	} else {
+		lines = append(lines, b.Colors.Accent.Sprint(fit(item.Tag, width)))
+
+		for _, line := range toLines(item.Content) {
+			if len(lines) == height {
+				break
+			}
+
+			lines = append(lines, fit(line, width))
+		}
+	}
+
+	for len(lines) < height {
+		lines = append(lines, strings.Repeat(" ", width))
+	}
+
+	return lines
+}
+
+func dropLastRune(s string) string {
+	r := []rune(s)
+	if len(r) == 0 {
+		return s
+	}
+
+	return string(r[:len(r)-1])
+}
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"unicode"
+)
+
+// Scoring of the fuzzy matching, inspired by fzf.
+const (
+	scoreMatch       = 16
+	bonusConsecutive = 12
+	bonusBoundary    = 8
+	penaltyGap       = 3
+)
+
+// Checks if every character of the pattern appears in order in the text,
+// ignoring the case. Returns the score of the best alignment found along
+// with the positions(in runes) of the matched characters.
+func FuzzyMatch(pattern, text string) (int, []int, bool) {
+	p := []rune(toLower(pattern))
+	t := []rune(toLower(text))
+
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+
+	bestScore, bestPositions, found := 0, []int(nil), false
+
+	// Every occurrence of the first character is a candidate to start the alignment
+	for start := range t {
+		if t[start] != p[0] {
+			continue
+		}
+
+		score, positions, ok := alignFrom(p, t, start)
+		if ok && (!found || score > bestScore) {
+			bestScore, bestPositions, found = score, positions, true
+		}
+	}
+
+	return bestScore, bestPositions, found
+}
+
+func alignFrom(p, t []rune, start int) (int, []int, bool) {
+	positions := make([]int, 0, len(p))
+	score, j := 0, 0
+
+	for i := start; i < len(t) && j < len(p); i++ {
+		if t[i] != p[j] {
+			continue
+		}
+
+		score += scoreMatch
+
+		if i == 0 || isBoundary(t[i-1]) {
+			score += bonusBoundary
+		}
+
+		if len(positions) > 0 {
+			if last := positions[len(positions)-1]; last == i-1 {
+				score += bonusConsecutive
+			} else {
+				score -= (i - last - 1) * penaltyGap
+			}
+		}
+
+		positions = append(positions, i)
+		j++
+	}
+
+	return score, positions, j == len(p)
+}
+
+func isBoundary(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r)
+}
+
+func toLower(s string) string {
+	r := []rune(s)
+
+	for i := range r {
+		r[i] = unicode.ToLower(r[i])
+	}
+
+	return string(r)
+}
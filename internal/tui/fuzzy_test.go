@@ -0,0 +1,48 @@
+package tui_test
+
+import (
+	"testing"
+
+	"github.com/luisnquin/nao/v3/internal/tui"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	checks := []struct {
+		pattern, text string
+		ok            bool
+	}{
+		{pattern: "", text: "anything", ok: true},
+		{pattern: "gcr", text: "groceries", ok: true},
+		{pattern: "GROC", text: "groceries", ok: true},
+		{pattern: "sg", text: "groceries", ok: false},
+		{pattern: "todo", text: "work-notes  3fa1b2c4d5  TODO: finish", ok: true},
+		{pattern: "xyz", text: "groceries", ok: false},
+	}
+
+	for _, expected := range checks {
+		if _, _, ok := tui.FuzzyMatch(expected.pattern, expected.text); ok != expected.ok {
+			t.Errorf("expected %t matching '%s' against '%s'", expected.ok, expected.pattern, expected.text)
+		}
+	}
+}
+
+func TestFuzzyMatchRanking(t *testing.T) {
+	// Consecutive and word-boundary matches must be preferred over scattered ones
+	checks := []struct {
+		pattern, better, worse string
+	}{
+		{pattern: "work", better: "work-notes", worse: "w-o-r-k"},
+		{pattern: "not", better: "work-notes", worse: "winter-oat"},
+		{pattern: "db", better: "db-migrations", worse: "feedback"},
+	}
+
+	for _, expected := range checks {
+		better, _, _ := tui.FuzzyMatch(expected.pattern, expected.better)
+		worse, _, _ := tui.FuzzyMatch(expected.pattern, expected.worse)
+
+		if better <= worse {
+			t.Errorf("expected '%s'(%d) to score more than '%s'(%d) for '%s'",
+				expected.better, better, expected.worse, worse, expected.pattern)
+		}
+	}
+}
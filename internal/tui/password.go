@@ -0,0 +1,30 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+)
+
+var ErrNoTerminal = errors.New("a terminal is required to type the passphrase")
+
+// Prompts for a secret in the controlling terminal without echoing it, so
+// it works even if the standard input and output are redirected.
+func ReadPassword(prompt string) (string, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return "", ErrNoTerminal
+	}
+
+	defer tty.Close()
+
+	fmt.Fprint(tty, prompt)
+
+	password, err := term.ReadPassword(int(tty.Fd()))
+
+	fmt.Fprintln(tty)
+
+	return string(password), err
+}
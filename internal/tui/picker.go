@@ -0,0 +1,186 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gookit/color"
+)
+
+var ErrCanceled = errors.New("nothing was selected")
+
+// A selectable element of the picker, all the fields are used
+// when matching.
+type Candidate struct {
+	Key    string
+	Tag    string
+	Detail string
+	// Carried along with the candidate, it's neither displayed nor matched.
+	Value string
+}
+
+func (c Candidate) text() string {
+	return c.Tag + "  " + c.Key + "  " + c.Detail
+}
+
+type PickerColors struct {
+	Accent color.PrinterFace
+	Match  color.PrinterFace
+}
+
+// Picker is an interactive fuzzy finder, it draws in the controlling
+// terminal so the caller can still write the result to stdout.
+type Picker struct {
+	Prompt     string
+	Query      string
+	Colors     PickerColors
+	Candidates []Candidate
+
+	matches []pickerMatch
+	cursor  int
+	offset  int
+}
+
+type pickerMatch struct {
+	index     int
+	score     int
+	positions []int
+}
+
+// Blocks until a candidate is chosen, returns ErrCanceled if the
+// user gives up.
+func (p *Picker) Pick() (Candidate, error) {
+	if len(p.Candidates) == 0 {
+		return Candidate{}, ErrCanceled
+	}
+
+	t, err := OpenTTY()
+	if err != nil {
+		return Candidate{}, err
+	}
+
+	defer t.Close()
+
+	p.filter()
+
+	for {
+		if err := t.Draw(p.render(t)); err != nil {
+			return Candidate{}, err
+		}
+
+		key, err := t.ReadKey()
+		if err != nil {
+			return Candidate{}, err
+		}
+
+		switch key.Code {
+		case KeyEnter:
+			if len(p.matches) == 0 {
+				continue
+			}
+
+			return p.Candidates[p.matches[p.cursor].index], nil
+		case KeyEscape, KeyCtrlC, KeyCtrlD:
+			return Candidate{}, ErrCanceled
+		case KeyUp, KeyCtrlP:
+			p.move(-1)
+		case KeyDown, KeyCtrlN, KeyTab:
+			p.move(1)
+		case KeyPageUp:
+			p.move(-10)
+		case KeyPageDown:
+			p.move(10)
+		case KeyBackspace:
+			p.Query = dropLastRune(p.Query)
+			p.filter()
+		case KeyCtrlU:
+			p.Query = ""
+			p.filter()
+		case KeyRune:
+			p.Query += string(key.Rune)
+			p.filter()
+		}
+	}
+}
+
+// Ranks the candidates by their score, keeping the original order
+// between candidates with the same score.
+func (p *Picker) filter() {
+	p.matches = p.matches[:0]
+
+	for i, candidate := range p.Candidates {
+		score, positions, ok := FuzzyMatch(p.Query, candidate.text())
+		if ok {
+			p.matches = append(p.matches, pickerMatch{index: i, score: score, positions: positions})
+		}
+	}
+
+	sort.SliceStable(p.matches, func(i, j int) bool {
+		return p.matches[i].score > p.matches[j].score
+	})
+
+	p.cursor, p.offset = 0, 0
+}
+
+func (p *Picker) move(delta int) {
+	p.cursor += delta
+
+	if p.cursor >= len(p.matches) {
+		p.cursor = len(p.matches) - 1
+	}
+
+	if p.cursor < 0 {
+		p.cursor = 0
+	}
+}
+
+func (p *Picker) render(t *Terminal) string {
+	width, height := t.Size()
+	rows := height - 2
+
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+
+	if p.cursor >= p.offset+rows {
+		p.offset = p.cursor - rows + 1
+	}
+
+	var b strings.Builder
+
+	b.WriteString(p.Colors.Accent.Sprint(p.Prompt + "> "))
+	b.WriteString(p.Query)
+	b.WriteString("▏\r\n")
+	b.WriteString(p.Colors.Accent.Sprint(fit(fmt.Sprintf("  %d/%d", len(p.matches), len(p.Candidates)), width)))
+
+	for i := p.offset; i < len(p.matches) && i < p.offset+rows; i++ {
+		match := p.matches[i]
+		line := []rune(fit(p.Candidates[match.index].text(), width-2))
+
+		b.WriteString("\r\n")
+
+		if i == p.cursor {
+			b.WriteString(p.Colors.Accent.Sprint("▌ "))
+		} else {
+			b.WriteString("  ")
+		}
+
+		highlighted := make(map[int]bool, len(match.positions))
+
+		for _, position := range match.positions {
+			highlighted[position] = true
+		}
+
+		for j, r := range line {
+			if highlighted[j] {
+				b.WriteString(p.Colors.Match.Sprint(string(r)))
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+
+	return b.String()
+}
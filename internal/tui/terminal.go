@@ -0,0 +1,207 @@
+package tui
+
+import (
+	"os"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+type KeyCode int
+
+// Recognized keys, printable characters are reported as KeyRune.
+const (
+	KeyUnknown KeyCode = iota
+	KeyRune
+	KeyEnter
+	KeyEscape
+	KeyBackspace
+	KeyTab
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyHome
+	KeyEnd
+	KeyPageUp
+	KeyPageDown
+	KeyCtrlC
+	KeyCtrlD
+	KeyCtrlU
+	KeyCtrlN
+	KeyCtrlP
+)
+
+type Key struct {
+	Code KeyCode
+	Rune rune
+}
+
+// Terminal is a minimal full-screen terminal, it uses the alternate
+// screen buffer so the previous content is restored on close.
+type Terminal struct {
+	in, out *os.File
+	state   *term.State
+}
+
+// Checks that both, the standard input and output are terminals.
+func IsInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// Checks that the standard input is a terminal, the output could
+// still be redirected.
+func IsInputInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+func Open() (*Terminal, error) {
+	t := &Terminal{in: os.Stdin, out: os.Stdout}
+
+	return t, t.Resume()
+}
+
+// Like Open but drawing in the controlling terminal, this allows the
+// standard output to be captured by other programs.
+func OpenTTY() (*Terminal, error) {
+	t := &Terminal{in: os.Stdin, out: os.Stderr}
+
+	if tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0); err == nil {
+		t.in, t.out = tty, tty
+	}
+
+	return t, t.Resume()
+}
+
+// Puts the terminal in raw mode and switches to the alternate screen.
+func (t *Terminal) Resume() error {
+	state, err := term.MakeRaw(int(t.in.Fd()))
+	if err != nil {
+		return err
+	}
+
+	t.state = state
+
+	_, err = t.out.WriteString("\x1b[?1049h\x1b[?25l")
+
+	return err
+}
+
+// Restores the terminal to the state it had before calling Resume, useful
+// to give the control to another program such as the editor.
+func (t *Terminal) Suspend() error {
+	if t.state == nil {
+		return nil
+	}
+
+	_, _ = t.out.WriteString("\x1b[?25h\x1b[?1049l")
+
+	err := term.Restore(int(t.in.Fd()), t.state)
+	t.state = nil
+
+	return err
+}
+
+func (t *Terminal) Close() error {
+	err := t.Suspend()
+
+	if t.in != os.Stdin {
+		t.in.Close()
+	}
+
+	return err
+}
+
+// Returns the width and height of the terminal, with a fallback
+// in case they can't be determined.
+func (t *Terminal) Size() (int, int) {
+	width, height, err := term.GetSize(int(t.out.Fd()))
+	if err != nil || width == 0 || height == 0 {
+		return 80, 24
+	}
+
+	return width, height
+}
+
+// Draws the frame at the top left of the screen, clearing everything else.
+func (t *Terminal) Draw(frame string) error {
+	_, err := t.out.WriteString("\x1b[H\x1b[2J" + frame)
+
+	return err
+}
+
+func (t *Terminal) Write(p []byte) (int, error) {
+	return t.out.Write(p)
+}
+
+func (t *Terminal) ReadKey() (Key, error) {
+	buf := make([]byte, 32)
+
+	n, err := t.in.Read(buf)
+	if err != nil {
+		return Key{}, err
+	}
+
+	return parseKey(buf[:n]), nil
+}
+
+func parseKey(b []byte) Key {
+	if len(b) == 0 {
+		return Key{}
+	}
+
+	switch b[0] {
+	case '\r', '\n':
+		return Key{Code: KeyEnter}
+	case '\t':
+		return Key{Code: KeyTab}
+	case 0x7f, 0x08:
+		return Key{Code: KeyBackspace}
+	case 0x03:
+		return Key{Code: KeyCtrlC}
+	case 0x04:
+		return Key{Code: KeyCtrlD}
+	case 0x15:
+		return Key{Code: KeyCtrlU}
+	case 0x0e:
+		return Key{Code: KeyCtrlN}
+	case 0x10:
+		return Key{Code: KeyCtrlP}
+	case 0x1b:
+		return parseEscapeSequence(b)
+	}
+
+	r, _ := utf8.DecodeRune(b)
+	if r == utf8.RuneError || r < 0x20 {
+		return Key{}
+	}
+
+	return Key{Code: KeyRune, Rune: r}
+}
+
+func parseEscapeSequence(b []byte) Key {
+	if len(b) == 1 {
+		return Key{Code: KeyEscape}
+	}
+
+	switch string(b[1:]) {
+	case "[A", "OA":
+		return Key{Code: KeyUp}
+	case "[B", "OB":
+		return Key{Code: KeyDown}
+	case "[C", "OC":
+		return Key{Code: KeyRight}
+	case "[D", "OD":
+		return Key{Code: KeyLeft}
+	case "[H", "OH", "[1~", "[7~":
+		return Key{Code: KeyHome}
+	case "[F", "OF", "[4~", "[8~":
+		return Key{Code: KeyEnd}
+	case "[5~":
+		return Key{Code: KeyPageUp}
+	case "[6~":
+		return Key{Code: KeyPageDown}
+	}
+
+	return Key{}
+}
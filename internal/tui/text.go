@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// Truncates or pads the string to occupy exactly the given width in
+// terminal cells.
+func fit(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+
+	if runewidth.StringWidth(s) > width {
+		s = runewidth.Truncate(s, width, "…")
+	}
+
+	return runewidth.FillRight(s, width)
+}
+
+// Splits the text in lines, expanding tabs and removing control
+// characters that would break the layout.
+func toLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\t", "    ")
+
+	lines := strings.Split(text, "\n")
+
+	for i, line := range lines {
+		lines[i] = strings.Map(func(r rune) rune {
+			if r < 0x20 || r == 0x7f {
+				return -1
+			}
+
+			return r
+		}, line)
+	}
+
+	return lines
+}
@@ -30,6 +30,24 @@ func GetPrinter(c string) color.PrinterFace {
 	return color.Normal
 }
 
+// Checks if the provided string is a color that GetPrinter can use.
+func IsColor(c string) bool {
+	if IsHex(c) {
+		return true
+	}
+
+	if cInt, err := strconv.Atoi(c); err == nil {
+		return cInt >= 0 && cInt < 256
+	}
+
+	_, ok := color.FgColors[c]
+	if !ok {
+		_, ok = color.ExFgColors[c]
+	}
+
+	return ok
+}
+
 // Checks if the provided string is a valid hexadecimal color.
 func IsHex(s string) bool {
 	return rxHexCode.MatchString(s)
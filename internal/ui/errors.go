@@ -5,16 +5,38 @@ import (
 	"os"
 
 	"github.com/gookit/color"
+	"github.com/luisnquin/nao/v3/internal"
 )
 
+// The colors of the messages, replaced by the theme once the
+// configuration is loaded.
+var messageColors = map[string]string{
+	RoleError:      "#e63758",
+	RoleSuggestion: "#deda73",
+}
+
+// Changes the color used by the messages of the role, an empty code
+// disables it.
+func SetMessageColor(role, code string) {
+	messageColors[role] = code
+}
+
+func messagePrinter(role string) color.PrinterFace {
+	if internal.NoColor || messageColors[role] == "" {
+		return color.Normal
+	}
+
+	return GetPrinter(messageColors[role])
+}
+
 type Suggest struct{}
 
 func (s Suggest) Suggest(message string) {
-	fmt.Fprintf(os.Stderr, color.HEX("#deda73").Sprintf("\nSuggestion: %s\n", message))
+	fmt.Fprint(os.Stderr, messagePrinter(RoleSuggestion).Sprintf("\nSuggestion: %s\n", message))
 }
 
 func Error(message string) Suggest {
-	fmt.Fprint(os.Stderr, color.HEX("#e63758").Sprintf("Error: %s\n", message))
+	fmt.Fprint(os.Stderr, messagePrinter(RoleError).Sprintf("Error: %s\n", message))
 
 	return Suggest{}
 }
@@ -23,8 +45,14 @@ func Errorf(message string, more ...any) Suggest {
 	return Error(fmt.Sprintf(message, more...))
 }
 
+func Warning(message string) Suggest {
+	fmt.Fprint(os.Stderr, messagePrinter(RoleSuggestion).Sprintf("Warning: %s\n", message))
+
+	return Suggest{}
+}
+
 func Fatal(message string) Suggest {
-	fmt.Fprint(os.Stderr, color.HEX("#c41f3e").Sprintf("boom 💥, %s\n", message))
+	fmt.Fprint(os.Stderr, messagePrinter(RoleError).Sprintf("boom 💥, %s\n", message))
 
 	return Suggest{}
 }
@@ -9,7 +9,7 @@ import (
 )
 
 func YesOrNoPrompt(v *bool, format string, a ...any) {
-	fmt.Fprintf(os.Stdout, "%s: %s ", internal.AppName, fmt.Sprintf(format, a...))
+	fmt.Fprintf(os.Stdout, "%s %s ", messagePrinter(RolePrompt).Sprint(internal.AppName+":"), fmt.Sprintf(format, a...))
 
 	result := ""
 
@@ -1,18 +1,107 @@
 package ui
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/luisnquin/nao/v3/internal/utils"
+)
+
+var ErrInvalidTheme = errors.New("invalid theme")
 
 type ColorScheme struct {
-	Name  string
-	One   string
-	Two   string
-	Three string
-	Four  string
-	Five  string
-	Six   string
-	Seven string
-	Eight string
-	Nine  string
+	Name  string `yaml:"name"`
+	One   string `yaml:"one,omitempty"`
+	Two   string `yaml:"two,omitempty"`
+	Three string `yaml:"three,omitempty"`
+	Four  string `yaml:"four,omitempty"`
+	Five  string `yaml:"five,omitempty"`
+	Six   string `yaml:"six,omitempty"`
+	Seven string `yaml:"seven,omitempty"`
+	Eight string `yaml:"eight,omitempty"`
+	Nine  string `yaml:"nine,omitempty"`
+	// Colors by role, the missing ones are derived from the slots.
+	Roles map[string]string `yaml:"roles,omitempty"`
+}
+
+// Color roles, the meaning of a color in the interface.
+const (
+	RoleHeader     = "header"
+	RoleAccent     = "accent"
+	RoleMatch      = "match"
+	RoleID         = "id"
+	RoleTag        = "tag"
+	RoleSize       = "size"
+	RoleDate       = "date"
+	RoleCreation   = "creation"
+	RoleDuration   = "duration"
+	RoleVersion    = "version"
+	RoleError      = "error"
+	RoleSuggestion = "suggestion"
+	RolePrompt     = "prompt"
+	RoleCode       = "code"
+	RoleKeyword    = "keyword"
+	RoleString     = "string"
+	RoleNumber     = "number"
+	RoleComment    = "comment"
+)
+
+func GetRoles() []string {
+	return []string{
+		RoleHeader,
+		RoleAccent,
+		RoleMatch,
+		RoleID,
+		RoleTag,
+		RoleSize,
+		RoleDate,
+		RoleCreation,
+		RoleDuration,
+		RoleVersion,
+		RoleError,
+		RoleSuggestion,
+		RolePrompt,
+		RoleCode,
+		RoleKeyword,
+		RoleString,
+		RoleNumber,
+		RoleComment,
+	}
+}
+
+// Returns the color of the role.
+func (c *ColorScheme) Role(role string) string {
+	if code := c.Roles[role]; code != "" {
+		return code
+	}
+
+	switch role {
+	case RoleHeader:
+		return c.Two
+	case RoleAccent, RolePrompt:
+		return c.One
+	case RoleID, RoleMatch, RoleCode:
+		return c.Three
+	case RoleTag, RoleKeyword:
+		return c.Four
+	case RoleSize, RoleString:
+		return c.Five
+	case RoleDate, RoleNumber:
+		return c.Six
+	case RoleCreation:
+		return c.Seven
+	case RoleDuration:
+		return c.Eight
+	case RoleVersion, RoleComment:
+		return c.Nine
+	case RoleError:
+		return "#e63758"
+	case RoleSuggestion:
+		return "#deda73"
+	}
+
+	return ""
 }
 
 func (c *ColorScheme) List() []string {
@@ -29,6 +118,35 @@ func (c *ColorScheme) Pretty() string {
 	return b.String()
 }
 
+// Checks that the theme has a name and that every color is supported by GetPrinter.
+func (c *ColorScheme) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return fmt.Errorf("%w: the name is required", ErrInvalidTheme)
+	}
+
+	slots := []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
+
+	for i, code := range c.List() {
+		if code != "" && !IsColor(code) {
+			return fmt.Errorf("%w '%s': unsupported color '%s' in '%s', expected a hex code like #5e81ac, a 256-color code or a color name",
+				ErrInvalidTheme, c.Name, code, slots[i])
+		}
+	}
+
+	for role, code := range c.Roles {
+		if !utils.Contains(GetRoles(), role) {
+			return fmt.Errorf("%w '%s': unknown role '%s', expected one of: %s",
+				ErrInvalidTheme, c.Name, role, strings.Join(GetRoles(), ", "))
+		}
+
+		if code != "" && !IsColor(code) {
+			return fmt.Errorf("%w '%s': unsupported color '%s' for the role '%s'", ErrInvalidTheme, c.Name, code, role)
+		}
+	}
+
+	return nil
+}
+
 // Theme names.
 const (
 	RosePineDawn = "rose-pine-dawn"
@@ -68,6 +186,21 @@ func GetThemes() []*ColorScheme {
 
 var NoTheme = new(ColorScheme)
 
+// Returns the built-in theme with the provided name.
+func GetTheme(name string) (*ColorScheme, bool) {
+	if name == Nop {
+		return &ColorScheme{Name: Nop}, true
+	}
+
+	for _, theme := range GetThemes() {
+		if theme.Name == name {
+			return theme, true
+		}
+	}
+
+	return nil, false
+}
+
 func GetDefaultTheme() *ColorScheme {
 	return &ColorScheme{
 		Name:  Default,
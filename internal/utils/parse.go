@@ -0,0 +1,258 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Parses a size like 512, 10KB or 1.5MB into bytes, the units are
+// powers of 1024 like in SizeToStorageUnits.
+func ParseSize(s string) (int, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.'
+	})
+
+	number, unit := s, ""
+	if i != -1 {
+		number, unit = s[:i], strings.TrimSpace(s[i:])
+	}
+
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size '%s', expected something like 512B, 10KB or 1.5MB", s)
+	}
+
+	switch strings.TrimSuffix(unit, "B") {
+	case "":
+	case "K":
+		n *= 1 << 10
+	case "M":
+		n *= 1 << 20
+	case "G":
+		n *= 1 << 30
+	default:
+		return 0, fmt.Errorf("invalid size unit '%s', expected B, KB, MB or GB", unit)
+	}
+
+	return int(n), nil
+}
+
+// Supported layouts for ParseTime.
+var timeLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04", dateLayout}
+
+const dateLayout = "2006-01-02"
+
+// Parses a date like 2006-01-02, 2006-01-02 15:04 or RFC3339 in local
+// time, or a duration relative to now like 30m, 12h, 3d or 2w.
+func ParseTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	if len(s) > 1 {
+		multiplier := time.Duration(0)
+
+		switch s[len(s)-1] {
+		case 'd':
+			multiplier = 24 * time.Hour
+		case 'w':
+			multiplier = 7 * 24 * time.Hour
+		}
+
+		if multiplier != 0 {
+			if n, err := strconv.Atoi(s[:len(s)-1]); err == nil && n >= 0 {
+				return now.Add(-time.Duration(n) * multiplier), nil
+			}
+		}
+	}
+
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date '%s', expected a date like 2006-01-02 or a duration like 12h, 3d or 2w", s)
+}
+
+// Parses a due date relative to now, in natural language like 'tomorrow 9am',
+// 'friday 17:30', 'tonight', 'in 2 hours' or '3d', or in the layouts of
+// ParseTime. The days without a time are at 9am and a time without a day is
+// the next one, without a time it must not be past.
+func ParseDue(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	// The layouts are case-sensitive, like the T and Z of RFC3339. The days
+	// without a time are parsed below, to be at 9am
+	for _, layout := range timeLayouts {
+		if layout == dateLayout {
+			continue
+		}
+
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	s = strings.ToLower(s)
+
+	if d, ok := parseFutureDuration(strings.TrimPrefix(s, "in ")); ok {
+		return now.Add(d), nil
+	}
+
+	invalid := fmt.Errorf("invalid due date '%s', expected something like 'tomorrow 9am', 'friday 17:30', 'in 2 hours' or 2006-01-02 15:04", s)
+
+	var day time.Time
+
+	hour, minute, hasTime := 9, 0, false
+	fields := strings.Fields(s)
+
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+
+		// Like '9 am'
+		if i+1 < len(fields) && (fields[i+1] == "am" || fields[i+1] == "pm") {
+			field += fields[i+1]
+			i++
+		}
+
+		switch field {
+		case "at", "next", "on":
+			if field == "next" && i+1 < len(fields) && fields[i+1] == "week" {
+				day = now.AddDate(0, 0, 7)
+				i++
+			}
+
+			continue
+		case "today":
+			day = now
+		case "tonight":
+			day = now
+
+			if !hasTime {
+				hour = 20
+			}
+		case "tomorrow":
+			day = now.AddDate(0, 0, 1)
+		default:
+			if weekday, ok := parseWeekday(field); ok {
+				days := (int(weekday) - int(now.Weekday()) + 7) % 7
+				if days == 0 {
+					days = 7
+				}
+
+				day = now.AddDate(0, 0, days)
+
+				continue
+			}
+
+			if t, err := time.ParseInLocation(dateLayout, field, now.Location()); err == nil {
+				day = t
+
+				continue
+			}
+
+			h, m, ok := parseClock(field)
+			if !ok {
+				return time.Time{}, invalid
+			}
+
+			hour, minute, hasTime = h, m, true
+		}
+	}
+
+	if day.IsZero() {
+		if !hasTime {
+			return time.Time{}, invalid
+		}
+
+		due := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+		if !due.After(now) {
+			due = due.AddDate(0, 0, 1)
+		}
+
+		return due, nil
+	}
+
+	due := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, now.Location())
+
+	// Like 'today' after 9am, the time was not chosen
+	if !hasTime && !due.After(now) {
+		return time.Time{}, fmt.Errorf("'%s' at %s has already passed, add a time like '%s 18:00'", s, due.Format("15:04"), s)
+	}
+
+	return due, nil
+}
+
+// Parses a duration like 2h, 30min, 3 days or 1w.
+func parseFutureDuration(s string) (time.Duration, bool) {
+	s = strings.ReplaceAll(s, " ", "")
+
+	i := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsDigit(r) })
+	if i <= 0 {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(s[:i])
+	if err != nil {
+		return 0, false
+	}
+
+	var unit time.Duration
+
+	switch s[i:] {
+	case "m", "min", "mins", "minute", "minutes":
+		unit = time.Minute
+	case "h", "hr", "hrs", "hour", "hours":
+		unit = time.Hour
+	case "d", "day", "days":
+		unit = 24 * time.Hour
+	case "w", "week", "weeks":
+		unit = 7 * 24 * time.Hour
+	default:
+		if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+			return d, true
+		}
+
+		return 0, false
+	}
+
+	return time.Duration(n) * unit, true
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+
+		if s == name || s == name[:3] {
+			return day, true
+		}
+	}
+
+	return 0, false
+}
+
+// Parses a time of the day like 9am, 9:30pm, 21:00, noon or midnight.
+func parseClock(s string) (hour, minute int, ok bool) {
+	switch s {
+	case "noon":
+		return 12, 0, true
+	case "midnight":
+		return 0, 0, true
+	}
+
+	for _, layout := range []string{"3pm", "3:04pm", "15:04"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Hour(), t.Minute(), true
+		}
+	}
+
+	return 0, 0, false
+}
@@ -0,0 +1,92 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luisnquin/nao/v3/internal/utils"
+)
+
+func TestParseSize(t *testing.T) {
+	checks := map[string]int{
+		"512":   512,
+		"512B":  512,
+		"10kb":  10 << 10,
+		"1.5MB": 3 << 19,
+		"2G":    2 << 30,
+	}
+
+	for in, out := range checks {
+		if size, err := utils.ParseSize(in); err != nil || size != out {
+			t.Errorf("expected %d from %q, got %d, %v", out, in, size, err)
+		}
+	}
+
+	for _, in := range []string{"", "KB", "-1KB", "10TB"} {
+		if _, err := utils.ParseSize(in); err == nil {
+			t.Errorf("expected an error from %q", in)
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2023, 5, 20, 12, 0, 0, 0, time.UTC)
+
+	checks := map[string]time.Time{
+		"2023-05-01":       time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC),
+		"2023-05-01 08:30": time.Date(2023, 5, 1, 8, 30, 0, 0, time.UTC),
+		"12h":              now.Add(-12 * time.Hour),
+		"3d":               now.Add(-72 * time.Hour),
+		"2w":               now.Add(-14 * 24 * time.Hour),
+	}
+
+	for in, out := range checks {
+		if date, err := utils.ParseTime(in, now); err != nil || !date.Equal(out) {
+			t.Errorf("expected %s from %q, got %s, %v", out, in, date, err)
+		}
+	}
+
+	if _, err := utils.ParseTime("yesterday", now); err == nil {
+		t.Error("expected an error from an unsupported date")
+	}
+}
+
+func TestParseDue(t *testing.T) {
+	// A Saturday
+	now := time.Date(2023, 5, 20, 12, 0, 0, 0, time.UTC)
+
+	checks := map[string]time.Time{
+		"2023-06-01 08:30":  time.Date(2023, 6, 1, 8, 30, 0, 0, time.UTC),
+		"in 2 hours":        now.Add(2 * time.Hour),
+		"3d":                now.Add(72 * time.Hour),
+		"1h30m":             now.Add(90 * time.Minute),
+		"tomorrow 9am":      time.Date(2023, 5, 21, 9, 0, 0, 0, time.UTC),
+		"tomorrow":          time.Date(2023, 5, 21, 9, 0, 0, 0, time.UTC),
+		"tonight":           time.Date(2023, 5, 20, 20, 0, 0, 0, time.UTC),
+		"friday 17:30":      time.Date(2023, 5, 26, 17, 30, 0, 0, time.UTC),
+		"next sat at 10 pm": time.Date(2023, 5, 27, 22, 0, 0, 0, time.UTC),
+		"3:15pm":            time.Date(2023, 5, 20, 15, 15, 0, 0, time.UTC),
+		"9am":               time.Date(2023, 5, 21, 9, 0, 0, 0, time.UTC),
+		"2023-06-01 noon":   time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+		"today 18:00":       time.Date(2023, 5, 20, 18, 0, 0, 0, time.UTC),
+		"2023-06-01":        time.Date(2023, 6, 1, 9, 0, 0, 0, time.UTC),
+		"2023-05-20 18:00":  time.Date(2023, 5, 20, 18, 0, 0, 0, time.UTC),
+
+		"2023-06-01T08:30:00Z":      time.Date(2023, 6, 1, 8, 30, 0, 0, time.UTC),
+		"2023-06-01T08:30:00+02:00": time.Date(2023, 6, 1, 6, 30, 0, 0, time.UTC),
+		"2023-06-01T08:30":          time.Date(2023, 6, 1, 8, 30, 0, 0, time.UTC),
+	}
+
+	for in, out := range checks {
+		if date, err := utils.ParseDue(in, now); err != nil || !date.Equal(out) {
+			t.Errorf("expected %s from %q, got %s, %v", out, in, date, err)
+		}
+	}
+
+	// Today at 9am has already passed
+	for _, in := range []string{"", "someday", "tomorrow 25:00", "today", "2023-05-20", "2023-05-19"} {
+		if _, err := utils.ParseDue(in, now); err == nil {
+			t.Errorf("expected an error from %q", in)
+		}
+	}
+}